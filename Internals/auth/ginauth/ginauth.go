@@ -0,0 +1,38 @@
+// Package ginauth adapts the auth middleware to gin
+package ginauth
+
+import (
+	"net/http"
+
+	"github.com/Thedrogon/blogbish/Internals/auth"
+	"github.com/Thedrogon/blogbish/Internals/problem"
+	"github.com/Thedrogon/blogbish/Internals/problem/ginproblem"
+	"github.com/gin-gonic/gin"
+)
+
+// Authenticate verifies the bearer token of requests that carry one and
+// records the caller as "user_id" in the gin context, where handlers look for
+// it, and for the access log. Requests without a token continue anonymously.
+func Authenticate(secret string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok, err := auth.BearerToken(c.Request)
+		if !ok {
+			c.Next()
+			return
+		}
+		if err != nil {
+			ginproblem.Error(c, http.StatusUnauthorized, problem.CodeUnauthorized, err.Error())
+			return
+		}
+
+		claims, err := auth.ParseToken(secret, token)
+		if err != nil {
+			ginproblem.Error(c, http.StatusUnauthorized, problem.CodeUnauthorized, "token is invalid or expired")
+			return
+		}
+
+		c.Request = c.Request.WithContext(auth.WithClaims(c.Request.Context(), claims))
+		c.Set("user_id", claims.UserID)
+		c.Next()
+	}
+}
@@ -0,0 +1,25 @@
+// Package cachekeys names the Redis key prefixes the services cache under, so
+// the services and the blogbish CLI cannot drift apart on them.
+package cachekeys
+
+// Post service
+const (
+	Post     = "post:"
+	Category = "category:"
+	Feed     = "feed:"
+	Sitemap  = "sitemap:"
+)
+
+// Media service
+const Media = "media:"
+
+// Search service
+const (
+	Search  = "search:"
+	Suggest = "suggest:"
+)
+
+// Pattern returns the SCAN pattern matching every key under prefix
+func Pattern(prefix string) string {
+	return prefix + "*"
+}
@@ -0,0 +1,94 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+const (
+	// defaultShutdownDelay covers a few readiness probe periods of a typical
+	// load balancer
+	defaultShutdownDelay = 5 * time.Second
+
+	// shutdownTimeout bounds draining requests and running the closers
+	shutdownTimeout = 30 * time.Second
+)
+
+// Closer releases a resource once the server has stopped taking requests
+type Closer struct {
+	Name  string
+	Close func(ctx context.Context) error
+}
+
+// ShutdownDelay is how long a server keeps serving after SetShuttingDown, so
+// load balancers see the failing readiness probe and stop routing to it
+// before the listener closes. It is read from SHUTDOWN_DELAY, e.g. "10s".
+func ShutdownDelay() (time.Duration, error) {
+	value := os.Getenv("SHUTDOWN_DELAY")
+	if value == "" {
+		return defaultShutdownDelay, nil
+	}
+
+	delay, err := time.ParseDuration(value)
+	if err != nil || delay < 0 {
+		return 0, fmt.Errorf("invalid SHUTDOWN_DELAY %q: must be a non-negative duration", value)
+	}
+	return delay, nil
+}
+
+// Serve runs srv until SIGINT or SIGTERM, then shuts it down gracefully: the
+// readiness probe fails for ShutdownDelay, the listener closes and in-flight
+// requests drain, and finally closers run in order. Draining and closing
+// share one deadline. It returns an error if the server cannot start or stop.
+func Serve(srv *http.Server, checker *Checker, logger *slog.Logger, closers ...Closer) error {
+	delay, err := ShutdownDelay()
+	if err != nil {
+		return err
+	}
+
+	serverErrors := make(chan error, 1)
+	go func() {
+		logger.Info("server starting", "addr", srv.Addr)
+		serverErrors <- srv.ListenAndServe()
+	}()
+
+	shutdown := make(chan os.Signal, 1)
+	signal.Notify(shutdown, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(shutdown)
+
+	select {
+	case err := <-serverErrors:
+		return fmt.Errorf("server failed: %w", err)
+
+	case sig := <-shutdown:
+		logger.Info("start shutdown", "signal", sig.String(), "delay", delay.String())
+		checker.SetShuttingDown()
+		time.Sleep(delay)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := srv.Shutdown(ctx); err != nil {
+		logger.Error("graceful shutdown did not complete", "timeout", shutdownTimeout.String(), "error", err)
+		if err := srv.Close(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("could not stop http server: %w", err)
+		}
+	}
+
+	for _, closer := range closers {
+		if err := closer.Close(ctx); err != nil {
+			logger.Error("error during shutdown", "resource", closer.Name, "error", err)
+		}
+	}
+
+	logger.Info("shutdown complete")
+	return nil
+}
@@ -0,0 +1,54 @@
+// Package ginlog adapts the logging middleware to gin
+package ginlog
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/Thedrogon/blogbish/Internals/logging"
+	"github.com/gin-gonic/gin"
+)
+
+// RequestID picks up the X-Request-ID forwarded by the gateway, generating one
+// when the service is called directly
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := logging.ForwardedRequestID(c.Request)
+
+		c.Header(logging.RequestIDHeader, id)
+		c.Request = c.Request.WithContext(logging.ContextWithRequestID(c.Request.Context(), id))
+
+		c.Next()
+	}
+}
+
+// AccessLog writes one structured log line per request
+func AccessLog(logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		ctx := c.Request.Context()
+		status := c.Writer.Status()
+		attrs := []slog.Attr{
+			slog.String("request_id", logging.RequestIDFromContext(ctx)),
+			slog.String("method", c.Request.Method),
+			slog.String("route", c.FullPath()),
+			slog.String("path", c.Request.URL.Path),
+			slog.Int("status", status),
+			slog.Int("bytes", c.Writer.Size()),
+			slog.Duration("latency", time.Since(start)),
+			slog.String("remote_addr", c.Request.RemoteAddr),
+		}
+
+		if userID := logging.UserIDFromContext(ctx); userID != 0 {
+			attrs = append(attrs, slog.Int64("user_id", userID))
+		}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, slog.String("errors", c.Errors.String()))
+		}
+
+		logger.LogAttrs(ctx, logging.Level(status), "request", attrs...)
+	}
+}
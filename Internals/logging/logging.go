@@ -0,0 +1,158 @@
+// Package logging sets up structured logging for the gateway and services,
+// and correlates log lines of a request by its X-Request-ID. Gin services use
+// the middleware in the ginlog subpackage.
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// RequestIDHeader is the header used to correlate a request across the gateway and services
+const RequestIDHeader = "X-Request-ID"
+
+type contextKey int
+
+const requestInfoKey contextKey = iota
+
+// requestInfo is shared by pointer so handlers further down the chain
+// (e.g. the auth middleware) can annotate the access log entry
+type requestInfo struct {
+	requestID string
+	userID    int64
+}
+
+// New creates a JSON logger tagged with the service name and installs it as the default logger
+func New(service string) *slog.Logger {
+	level := slog.LevelInfo
+	if os.Getenv("LOG_LEVEL") == "debug" {
+		level = slog.LevelDebug
+	}
+
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: level})).
+		With("service", service)
+	slog.SetDefault(logger)
+
+	return logger
+}
+
+// RequestID accepts an incoming X-Request-ID or generates a new one, and makes it
+// available to downstream handlers, the proxied request and the response
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := ForwardedRequestID(r)
+
+		r.Header.Set(RequestIDHeader, id)
+		w.Header().Set(RequestIDHeader, id)
+
+		next.ServeHTTP(w, r.WithContext(ContextWithRequestID(r.Context(), id)))
+	})
+}
+
+// ForwardedRequestID returns the X-Request-ID of r, or a new one when it is
+// missing or implausibly long
+func ForwardedRequestID(r *http.Request) string {
+	id := r.Header.Get(RequestIDHeader)
+	if id == "" || len(id) > 128 {
+		id = newRequestID()
+	}
+	return id
+}
+
+// ContextWithRequestID returns a copy of ctx carrying the request ID id
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestInfoKey, &requestInfo{requestID: id})
+}
+
+// RequestIDFromContext returns the request ID stored by the RequestID middleware
+func RequestIDFromContext(ctx context.Context) string {
+	if info, ok := ctx.Value(requestInfoKey).(*requestInfo); ok {
+		return info.requestID
+	}
+	return ""
+}
+
+// SetUserID records the authenticated user for the access log entry
+func SetUserID(ctx context.Context, userID int64) {
+	if info, ok := ctx.Value(requestInfoKey).(*requestInfo); ok {
+		info.userID = userID
+	}
+}
+
+// UserIDFromContext returns the user recorded by SetUserID, or 0
+func UserIDFromContext(ctx context.Context) int64 {
+	if info, ok := ctx.Value(requestInfoKey).(*requestInfo); ok {
+		return info.userID
+	}
+	return 0
+}
+
+// FromContext returns the default logger annotated with the request ID, if any
+func FromContext(ctx context.Context) *slog.Logger {
+	if id := RequestIDFromContext(ctx); id != "" {
+		return slog.Default().With("request_id", id)
+	}
+	return slog.Default()
+}
+
+// AccessLog writes one structured log line per request
+func AccessLog(logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+			next.ServeHTTP(ww, r)
+
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+
+			route := ""
+			if rctx := chi.RouteContext(r.Context()); rctx != nil {
+				route = rctx.RoutePattern()
+			}
+
+			attrs := []slog.Attr{
+				slog.String("request_id", RequestIDFromContext(r.Context())),
+				slog.String("method", r.Method),
+				slog.String("route", route),
+				slog.String("path", r.URL.Path),
+				slog.Int("status", status),
+				slog.Int("bytes", ww.BytesWritten()),
+				slog.Duration("latency", time.Since(start)),
+				slog.String("remote_addr", r.RemoteAddr),
+			}
+			if userID := UserIDFromContext(r.Context()); userID != 0 {
+				attrs = append(attrs, slog.Int64("user_id", userID))
+			}
+
+			logger.LogAttrs(r.Context(), Level(status), "request", attrs...)
+		})
+	}
+}
+
+// Level is the level access log entries are written at for a response status
+func Level(status int) slog.Level {
+	if status >= http.StatusInternalServerError {
+		return slog.LevelError
+	}
+	return slog.LevelInfo
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return time.Now().Format("20060102150405.000000000")
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,25 @@
+// Package ginmetrics adapts the metrics middleware to gin
+package ginmetrics
+
+import (
+	"time"
+
+	"github.com/Thedrogon/blogbish/Internals/metrics"
+	"github.com/gin-gonic/gin"
+)
+
+// Middleware records request count, errors and latency labelled by gin route
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		metrics.ObserveRequest(c.Request.Method, c.FullPath(), c.Writer.Status(), time.Since(start))
+	}
+}
+
+// Handler exposes the registered metrics in the Prometheus text format
+func Handler() gin.HandlerFunc {
+	return gin.WrapH(metrics.Handler())
+}
@@ -0,0 +1,118 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Namespace prefixes every metric exported by the gateway and services
+const Namespace = "blogbish"
+
+var (
+	requestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "http_requests_total",
+		Help:      "Total number of HTTP requests by method, route and status code.",
+	}, []string{"method", "route", "status"})
+
+	requestErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "http_request_errors_total",
+		Help:      "Total number of HTTP requests that returned a 5xx status.",
+	}, []string{"method", "route"})
+
+	requestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by method and route.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	versionRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "api_version_requests_total",
+		Help:      "Total number of API requests by version and whether the version is deprecated.",
+	}, []string{"version", "deprecated"})
+
+	cacheHits = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "cache_hits_total",
+		Help:      "Total number of cache hits by cached entity.",
+	}, []string{"entity"})
+
+	cacheMisses = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "cache_misses_total",
+		Help:      "Total number of cache misses by cached entity.",
+	}, []string{"entity"})
+)
+
+// Middleware records request count, errors and latency labelled by chi route pattern
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+		next.ServeHTTP(ww, r)
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		route := ""
+		if rctx := chi.RouteContext(r.Context()); rctx != nil {
+			route = rctx.RoutePattern()
+		}
+
+		ObserveRequest(r.Method, route, status, time.Since(start))
+	})
+}
+
+// ObserveRequest records a served request; route is the matched route
+// pattern, or empty when no route matched
+func ObserveRequest(method, route string, status int, latency time.Duration) {
+	if route == "" {
+		route = "unmatched"
+	}
+
+	requestsTotal.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+	requestDuration.WithLabelValues(method, route).Observe(latency.Seconds())
+	if status >= http.StatusInternalServerError {
+		requestErrors.WithLabelValues(method, route).Inc()
+	}
+}
+
+// Handler exposes the registered metrics in the Prometheus text format
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// VersionRequest counts a request served through the given API version
+func VersionRequest(version string, deprecated bool) {
+	versionRequests.WithLabelValues(version, strconv.FormatBool(deprecated)).Inc()
+}
+
+// RegisterDBStats exports connection pool statistics for db
+func RegisterDBStats(db *sql.DB, name string) {
+	prometheus.MustRegister(collectors.NewDBStatsCollector(db, name))
+}
+
+// CacheHit counts a cache hit for the given entity (e.g. "post")
+func CacheHit(entity string) {
+	cacheHits.WithLabelValues(entity).Inc()
+}
+
+// CacheMiss counts a cache miss for the given entity
+func CacheMiss(entity string) {
+	cacheMisses.WithLabelValues(entity).Inc()
+}
@@ -0,0 +1,336 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Version is the OpenAPI specification version produced by Builder
+const Version = "3.0.3"
+
+// Document is an OpenAPI 3 document
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// PathItem maps lower-case HTTP methods to operations
+type PathItem map[string]*Operation
+
+type Operation struct {
+	OperationID string                `json:"operationId"`
+	Summary     string                `json:"summary,omitempty"`
+	Tags        []string              `json:"tags,omitempty"`
+	Parameters  []Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]Response   `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Response struct {
+	Description string               `json:"description"`
+	Headers     map[string]Header    `json:"headers,omitempty"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type Header struct {
+	Description string  `json:"description,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema        `json:"schemas,omitempty"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+}
+
+// Schema is the subset of the OpenAPI schema object the services need
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+// Builder assembles a Document, deriving component schemas from Go types
+// so the spec stays in step with the models
+type Builder struct {
+	doc *Document
+}
+
+// NewBuilder starts a document for the named service
+func NewBuilder(title, version, description string) *Builder {
+	return &Builder{doc: &Document{
+		OpenAPI: Version,
+		Info:    Info{Title: title, Description: description, Version: version},
+		Paths:   make(map[string]PathItem),
+		Components: Components{
+			Schemas: make(map[string]*Schema),
+			SecuritySchemes: map[string]SecurityScheme{
+				"bearerAuth": {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+			},
+		},
+	}}
+}
+
+// Add registers op for method and path; path uses {param} placeholders
+func (b *Builder) Add(method, path string, op Operation) {
+	item, ok := b.doc.Paths[path]
+	if !ok {
+		item = make(PathItem)
+		b.doc.Paths[path] = item
+	}
+	if op.Responses == nil {
+		op.Responses = make(map[string]Response)
+	}
+	item[strings.ToLower(method)] = &op
+}
+
+// Document returns the assembled document
+func (b *Builder) Document() *Document {
+	return b.doc
+}
+
+// Handler serves doc as JSON
+func Handler(doc *Document) http.HandlerFunc {
+	body, err := json.Marshal(doc)
+	if err != nil {
+		panic("openapi: cannot encode document: " + err.Error())
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body)
+	}
+}
+
+// Ref registers the named struct type of v as a component and returns a reference to it
+func (b *Builder) Ref(v interface{}) *Schema {
+	return b.schemaOf(reflect.TypeOf(v))
+}
+
+// ArrayOf returns a schema for a JSON array of v
+func (b *Builder) ArrayOf(v interface{}) *Schema {
+	return &Schema{Type: "array", Items: b.Ref(v)}
+}
+
+// JSON wraps schema in a required application/json request body
+func JSON(schema *Schema) *RequestBody {
+	return &RequestBody{Required: true, Content: map[string]MediaType{"application/json": {Schema: schema}}}
+}
+
+// Returns describes a successful response; schema may be nil for empty bodies
+func Returns(description string, schema *Schema) Response {
+	r := Response{Description: description}
+	if schema != nil {
+		r.Content = map[string]MediaType{"application/json": {Schema: schema}}
+	}
+	return r
+}
+
+// Paged adds the headers describing a page of a cursor-paginated listing to r
+func Paged(r Response) Response {
+	r.Headers = map[string]Header{
+		"X-Total-Count": {Description: "Number of matching items across all pages", Schema: &Schema{Type: "integer"}},
+		"X-Next-Cursor": {Description: "Cursor of the next page, absent on the last page", Schema: &Schema{Type: "string"}},
+		"Link":          {Description: `rel="next" and rel="first" links to other pages`, Schema: &Schema{Type: "string"}},
+	}
+	return r
+}
+
+// Fails describes an error response carrying problem details
+func (b *Builder) Fails(description string, problem interface{}) Response {
+	return Response{
+		Description: description,
+		Content:     map[string]MediaType{"application/problem+json": {Schema: b.Ref(problem)}},
+	}
+}
+
+// PathParam describes a required path parameter
+func PathParam(name, typ, description string) Parameter {
+	return Parameter{Name: name, In: "path", Required: true, Description: description, Schema: &Schema{Type: typ}}
+}
+
+// QueryParam describes an optional query parameter
+func QueryParam(name, typ, description string) Parameter {
+	return Parameter{Name: name, In: "query", Description: description, Schema: &Schema{Type: typ}}
+}
+
+// BearerAuth marks an operation as requiring a JWT
+var BearerAuth = []map[string][]string{{"bearerAuth": {}}}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func (b *Builder) schemaOf(t reflect.Type) *Schema {
+	nullable := false
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+		nullable = true
+	}
+
+	var s *Schema
+	switch {
+	case t == timeType:
+		s = &Schema{Type: "string", Format: "date-time"}
+	case t.Kind() == reflect.Struct && t.Name() != "":
+		b.register(t)
+		// $ref siblings are ignored, so nullable references are left as plain refs
+		return &Schema{Ref: "#/components/schemas/" + t.Name()}
+	case t.Kind() == reflect.Struct:
+		s = b.objectOf(t)
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+		s = &Schema{Type: "array", Items: b.schemaOf(t.Elem())}
+	case t.Kind() == reflect.Map:
+		s = &Schema{Type: "object", AdditionalProperties: b.schemaOf(t.Elem())}
+	case t.Kind() == reflect.String:
+		s = &Schema{Type: "string"}
+	case t.Kind() == reflect.Bool:
+		s = &Schema{Type: "boolean"}
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Uint64:
+		s = &Schema{Type: "integer"}
+		if t.Kind() == reflect.Int64 || t.Kind() == reflect.Uint64 {
+			s.Format = "int64"
+		}
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		s = &Schema{Type: "number"}
+	default:
+		// interface{} and anything else accepts any JSON value
+		s = &Schema{}
+	}
+
+	s.Nullable = nullable
+	return s
+}
+
+func (b *Builder) register(t reflect.Type) {
+	if _, ok := b.doc.Components.Schemas[t.Name()]; ok {
+		return
+	}
+	// Reserve the name first so self-referencing types terminate
+	b.doc.Components.Schemas[t.Name()] = &Schema{}
+	*b.doc.Components.Schemas[t.Name()] = *b.objectOf(t)
+}
+
+func (b *Builder) objectOf(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		// Embedded structs are flattened, as encoding/json does
+		if f.Anonymous && f.Type.Kind() == reflect.Struct && f.Tag.Get("json") == "" {
+			embedded := b.objectOf(f.Type)
+			for name, prop := range embedded.Properties {
+				s.Properties[name] = prop
+			}
+			s.Required = append(s.Required, embedded.Required...)
+			continue
+		}
+
+		name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		prop := b.schemaOf(f.Type)
+		rules := f.Tag.Get("validate")
+		if rules == "" {
+			rules = f.Tag.Get("binding")
+		}
+		if applyRules(prop, rules) && !strings.Contains(opts, "omitempty") {
+			s.Required = append(s.Required, name)
+		}
+		s.Properties[name] = prop
+	}
+
+	return s
+}
+
+// applyRules copies validator rules onto s and reports whether the field is required
+func applyRules(s *Schema, rules string) bool {
+	required := false
+	for _, rule := range strings.Split(rules, ",") {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "required":
+			required = true
+		case "email":
+			s.Format = "email"
+		case "url":
+			s.Format = "uri"
+		case "oneof":
+			s.Enum = strings.Fields(param)
+		case "min", "max":
+			n, err := strconv.Atoi(param)
+			if err != nil {
+				continue
+			}
+			switch s.Type {
+			case "string":
+				if name == "min" {
+					s.MinLength = &n
+				} else {
+					s.MaxLength = &n
+				}
+			case "integer", "number":
+				f := float64(n)
+				if name == "min" {
+					s.Minimum = &f
+				} else {
+					s.Maximum = &f
+				}
+			}
+		}
+	}
+	return required
+}
@@ -0,0 +1,241 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Thedrogon/blogbish/Internals/logging"
+	"github.com/Thedrogon/blogbish/Internals/problem"
+)
+
+// Source is a service whose OpenAPI document is merged into the gateway's
+type Source struct {
+	Name    string
+	BaseURL string
+}
+
+// Mapping exposes an upstream operation under a gateway path
+type Mapping struct {
+	Method   string
+	Path     string
+	Upstream string
+}
+
+// Aggregator merges the services' documents into one, rewriting upstream
+// paths to the gateway paths that expose them. Operations the gateway does
+// not route are left out.
+type Aggregator struct {
+	sources []Source
+	routes  map[string]string
+	client  *http.Client
+	ttl     time.Duration
+
+	mu        sync.Mutex
+	cached    []byte
+	fetchedAt time.Time
+}
+
+// NewAggregator creates an Aggregator that refreshes the merged document at most once per ttl
+func NewAggregator(sources []Source, mappings []Mapping, client *http.Client, ttl time.Duration) *Aggregator {
+	routes := make(map[string]string, len(mappings))
+	for _, m := range mappings {
+		routes[routeKey(m.Method, m.Upstream)] = m.Path
+	}
+
+	return &Aggregator{
+		sources: sources,
+		routes:  routes,
+		client:  client,
+		ttl:     ttl,
+	}
+}
+
+// ServeHTTP serves the merged document, falling back to the last good copy
+// when no service can be reached
+func (a *Aggregator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := a.document(r.Context())
+	if err != nil {
+		logging.FromContext(r.Context()).Error("error building OpenAPI document", "error", err)
+		problem.Error(w, r, http.StatusServiceUnavailable, problem.CodeUpstreamUnavailable, "API documentation is temporarily unavailable")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
+
+func (a *Aggregator) document(ctx context.Context) ([]byte, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.cached != nil && time.Since(a.fetchedAt) < a.ttl {
+		return a.cached, nil
+	}
+
+	body, err := a.merge(ctx)
+	if err != nil {
+		if a.cached != nil {
+			return a.cached, nil
+		}
+		return nil, err
+	}
+
+	a.cached = body
+	a.fetchedAt = time.Now()
+	return body, nil
+}
+
+func (a *Aggregator) merge(ctx context.Context) ([]byte, error) {
+	paths := map[string]map[string]interface{}{}
+	schemas := map[string]interface{}{}
+	securitySchemes := map[string]interface{}{}
+	var unavailable []string
+
+	// Fetched concurrently so one slow service delays the document by at most
+	// the fetch timeout, but merged in order so schema renames are stable
+	docs := make([]map[string]interface{}, len(a.sources))
+	errs := make([]error, len(a.sources))
+	var wg sync.WaitGroup
+	for i, src := range a.sources {
+		wg.Add(1)
+		go func(i int, src Source) {
+			defer wg.Done()
+			docs[i], errs[i] = a.fetch(ctx, src)
+		}(i, src)
+	}
+	wg.Wait()
+
+	for i, src := range a.sources {
+		doc, err := docs[i], errs[i]
+		if err != nil {
+			logging.FromContext(ctx).Warn("error fetching OpenAPI document", "error", err, "service", src.Name)
+			unavailable = append(unavailable, src.Name)
+			continue
+		}
+
+		components, _ := doc["components"].(map[string]interface{})
+		srcSchemas, _ := components["schemas"].(map[string]interface{})
+
+		// Services name their schemas independently; prefix any name that
+		// another service already uses for a different shape
+		renames := map[string]string{}
+		for name, schema := range srcSchemas {
+			if existing, ok := schemas[name]; ok && !reflect.DeepEqual(existing, schema) {
+				renames[name] = strings.ToUpper(src.Name[:1]) + src.Name[1:] + name
+			}
+		}
+		if len(renames) > 0 {
+			rewriteRefs(doc, renames)
+		}
+
+		for name, schema := range srcSchemas {
+			if newName, ok := renames[name]; ok {
+				name = newName
+			}
+			schemas[name] = schema
+		}
+		if schemes, ok := components["securitySchemes"].(map[string]interface{}); ok {
+			for name, scheme := range schemes {
+				securitySchemes[name] = scheme
+			}
+		}
+
+		srcPaths, _ := doc["paths"].(map[string]interface{})
+		for path, item := range srcPaths {
+			ops, _ := item.(map[string]interface{})
+			for method, op := range ops {
+				gatewayPath, ok := a.routes[routeKey(method, src.BaseURL+path)]
+				if !ok {
+					continue
+				}
+				if paths[gatewayPath] == nil {
+					paths[gatewayPath] = map[string]interface{}{}
+				}
+				paths[gatewayPath][method] = op
+			}
+		}
+	}
+
+	if len(unavailable) == len(a.sources) {
+		return nil, fmt.Errorf("no service documents available")
+	}
+
+	description := "Public API of the Blogbish gateway, merged from the service specifications."
+	if len(unavailable) > 0 {
+		sort.Strings(unavailable)
+		description += fmt.Sprintf(" Currently missing: %s.", strings.Join(unavailable, ", "))
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":       "Blogbish API",
+			"version":     "2",
+			"description": description,
+		},
+		"servers": []map[string]string{{"url": "/"}},
+		"paths":   paths,
+		"components": map[string]interface{}{
+			"schemas":         schemas,
+			"securitySchemes": securitySchemes,
+		},
+	})
+}
+
+func (a *Aggregator) fetch(ctx context.Context, src Source) (map[string]interface{}, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, src.BaseURL+"/openapi.json", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(logging.RequestIDHeader, logging.RequestIDFromContext(ctx))
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	var doc map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to decode document: %w", err)
+	}
+	return doc, nil
+}
+
+// rewriteRefs renames component schemas and every $ref pointing at them
+func rewriteRefs(v interface{}, renames map[string]string) {
+	switch node := v.(type) {
+	case map[string]interface{}:
+		if ref, ok := node["$ref"].(string); ok {
+			name := strings.TrimPrefix(ref, "#/components/schemas/")
+			if newName, ok := renames[name]; ok {
+				node["$ref"] = "#/components/schemas/" + newName
+			}
+		}
+		for _, child := range node {
+			rewriteRefs(child, renames)
+		}
+	case []interface{}:
+		for _, child := range node {
+			rewriteRefs(child, renames)
+		}
+	}
+}
+
+func routeKey(method, upstream string) string {
+	return strings.ToUpper(method) + " " + upstream
+}
@@ -0,0 +1,38 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// swaggerUIVersion pins the swagger-ui-dist release loaded from the CDN
+const swaggerUIVersion = "5.17.14"
+
+const swaggerUIPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Blogbish API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@%[1]s/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@%[1]s/swagger-ui-bundle.js" crossorigin></script>
+  <script>
+    window.onload = () => {
+      window.ui = SwaggerUIBundle({ url: %[2]q, dom_id: "#swagger-ui" });
+    };
+  </script>
+</body>
+</html>
+`
+
+// SwaggerUI serves a Swagger UI page for the document at specURL
+func SwaggerUI(specURL string) http.HandlerFunc {
+	page := []byte(fmt.Sprintf(swaggerUIPage, swaggerUIVersion, specURL))
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(page)
+	}
+}
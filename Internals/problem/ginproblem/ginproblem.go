@@ -0,0 +1,79 @@
+// Package ginproblem writes problem details responses from gin handlers
+package ginproblem
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/Thedrogon/blogbish/Internals/logging"
+	"github.com/Thedrogon/blogbish/Internals/problem"
+	"github.com/Thedrogon/blogbish/Internals/validation/rules"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// Write aborts the request with p, filling in the instance and request ID
+func Write(c *gin.Context, p *problem.Problem) {
+	if p.Instance == "" {
+		p.Instance = c.Request.URL.Path
+	}
+	if p.RequestID == "" {
+		p.RequestID = logging.RequestIDFromContext(c.Request.Context())
+	}
+
+	// gin keeps an explicitly set Content-Type when rendering JSON
+	c.Header("Content-Type", problem.ContentType)
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.AbortWithStatusJSON(p.Status, p)
+}
+
+// Error aborts the request with a problem for status with the given code and detail
+func Error(c *gin.Context, status int, code, detail string) {
+	Write(c, problem.New(status, code, detail))
+}
+
+// WriteError maps err with known and writes it. Unexpected errors are logged
+// with the request ID so they can be traced from the client report.
+func WriteError(c *gin.Context, known problem.Mappings, err error) {
+	p := known.FromError(err)
+	if p.Status >= http.StatusInternalServerError {
+		logging.FromContext(c.Request.Context()).Error("request failed", "error", err)
+	}
+	Write(c, p)
+}
+
+// BindError reports a failed ShouldBindJSON, listing the fields that did not
+// pass their binding rules
+func BindError(c *gin.Context, err error) {
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		Error(c, http.StatusBadRequest, problem.CodeInvalidBody, "request body must be valid JSON")
+		return
+	}
+
+	p := problem.New(http.StatusUnprocessableEntity, problem.CodeValidation, "request body failed validation")
+	for _, fe := range verrs {
+		p.Errors = append(p.Errors, problem.FieldError{
+			Field:   toSnakeCase(fe.Field()),
+			Rule:    fe.Tag(),
+			Message: rules.Message(fe),
+		})
+	}
+	Write(c, p)
+}
+
+// toSnakeCase turns a Go field name such as PostID into its JSON name post_id
+func toSnakeCase(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		if r >= 'A' && r <= 'Z' {
+			if i > 0 && !(s[i-1] >= 'A' && s[i-1] <= 'Z') {
+				b.WriteByte('_')
+			}
+			r += 'a' - 'A'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,118 @@
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/Thedrogon/blogbish/Internals/logging"
+)
+
+// ContentType is the media type of RFC 7807 problem details responses
+const ContentType = "application/problem+json"
+
+// Stable error codes clients can match on; unlike detail messages these
+// never change. The services add their own domain codes.
+const (
+	CodeInvalidBody         = "invalid_body"
+	CodeValidation          = "validation_failed"
+	CodeInvalidInput        = "invalid_input"
+	CodeInvalidQuery        = "invalid_query"
+	CodeUnauthorized        = "unauthorized"
+	CodeForbidden           = "forbidden"
+	CodeNotFound            = "not_found"
+	CodeBodyTooLarge        = "body_too_large"
+	CodeUnsupportedMedia    = "unsupported_media_type"
+	CodeUpstreamUnavailable = "upstream_unavailable"
+	CodeGone                = "gone"
+	CodeInternal            = "internal_error"
+)
+
+// Problem is an RFC 7807 problem details object
+type Problem struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Code      string       `json:"code"`
+	Detail    string       `json:"detail,omitempty"`
+	Instance  string       `json:"instance,omitempty"`
+	RequestID string       `json:"request_id,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError describes why a single field of the request was rejected
+type FieldError struct {
+	Field   string `json:"field,omitempty"`
+	Rule    string `json:"rule,omitempty"`
+	Message string `json:"message"`
+}
+
+// New creates a problem for status with a stable code and a human-readable detail
+func New(status int, code, detail string) *Problem {
+	return &Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Code:   code,
+		Detail: detail,
+	}
+}
+
+// Mapping maps a domain error to the problem returned to clients
+type Mapping struct {
+	Err    error
+	Status int
+	Code   string
+	Detail string
+}
+
+// Mappings lists the domain errors of a service
+type Mappings []Mapping
+
+// FromError maps a domain error to a problem. Errors that are not part of the
+// domain become an opaque 500 so internal details never reach clients.
+func (m Mappings) FromError(err error) *Problem {
+	for _, mapping := range m {
+		if errors.Is(err, mapping.Err) {
+			return New(mapping.Status, mapping.Code, mapping.Detail)
+		}
+	}
+	return New(http.StatusInternalServerError, CodeInternal, "an unexpected error occurred")
+}
+
+// Write sends p as the response, filling in the instance and request ID from r
+func Write(w http.ResponseWriter, r *http.Request, p *Problem) {
+	if p.Instance == "" {
+		p.Instance = r.URL.Path
+	}
+	if p.RequestID == "" {
+		p.RequestID = logging.RequestIDFromContext(r.Context())
+	}
+
+	w.Header().Set("Content-Type", ContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(p.Status)
+	json.NewEncoder(w).Encode(p)
+}
+
+// Error writes a problem for status with the given code and detail
+func Error(w http.ResponseWriter, r *http.Request, status int, code, detail string) {
+	Write(w, r, New(status, code, detail))
+}
+
+// Invalid writes a 422 listing the fields that failed validation
+func Invalid(w http.ResponseWriter, r *http.Request, fields []FieldError) {
+	p := New(http.StatusUnprocessableEntity, CodeValidation, "request body failed validation")
+	p.Errors = fields
+	Write(w, r, p)
+}
+
+// WriteError maps err with known and writes it. Unexpected errors are logged
+// with the request ID so they can be traced from the client report.
+func WriteError(w http.ResponseWriter, r *http.Request, known Mappings, err error) {
+	p := known.FromError(err)
+	if p.Status >= http.StatusInternalServerError {
+		logging.FromContext(r.Context()).Error("request failed", "error", err)
+	}
+	Write(w, r, p)
+}
@@ -0,0 +1,125 @@
+package routes
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/Thedrogon/blogbish/Internals/logging"
+	"github.com/Thedrogon/blogbish/Internals/problem"
+	"github.com/Thedrogon/blogbish/Internals/validation"
+)
+
+const (
+	// defaultMaxBodyBytes caps JSON request bodies
+	defaultMaxBodyBytes = 1 << 20
+
+	// maxUploadBytes caps media uploads
+	maxUploadBytes = 32 << 20
+)
+
+var defaultContentTypes = []string{"application/json"}
+
+// enforceBody rejects oversized bodies, unexpected content types and, for
+// routes with a schema, JSON that does not validate, before anything is
+// sent upstream
+func enforceBody(route Route, next http.Handler) http.Handler {
+	if route.Schema != "" && !validation.Has(route.Schema) {
+		panic(fmt.Sprintf("route %s %s refers to unknown schema %q", route.Method, route.Pattern, route.Schema))
+	}
+
+	maxBytes := route.MaxBodyBytes
+	if maxBytes == 0 {
+		maxBytes = defaultMaxBodyBytes
+	}
+	contentTypes := route.ContentTypes
+	if len(contentTypes) == 0 {
+		contentTypes = defaultContentTypes
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength > maxBytes {
+			problem.Error(w, r, http.StatusRequestEntityTooLarge, problem.CodeBodyTooLarge,
+				fmt.Sprintf("request body must not exceed %d bytes", maxBytes))
+			return
+		}
+
+		// Requests without a body, such as likes or deletes, pass straight
+		// through. Content-Length is missing for chunked bodies and need not be
+		// honest, so this is decided on what can actually be read.
+		if r.Body == nil || r.Body == http.NoBody {
+			next.ServeHTTP(w, r)
+			return
+		}
+		limited := http.MaxBytesReader(w, r.Body, maxBytes)
+		buffered := bufio.NewReader(limited)
+		if _, err := buffered.Peek(1); errors.Is(err, io.EOF) {
+			r.Body = http.NoBody
+			r.ContentLength = 0
+			next.ServeHTTP(w, r)
+			return
+		}
+		r.Body = readCloser{Reader: buffered, Closer: limited}
+
+		mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if err != nil || !allowed(mediaType, contentTypes) {
+			problem.Error(w, r, http.StatusUnsupportedMediaType, problem.CodeUnsupportedMedia,
+				fmt.Sprintf("content type must be one of: %s", strings.Join(contentTypes, ", ")))
+			return
+		}
+
+		if route.Schema == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			var maxErr *http.MaxBytesError
+			if errors.As(err, &maxErr) {
+				problem.Error(w, r, http.StatusRequestEntityTooLarge, problem.CodeBodyTooLarge,
+					fmt.Sprintf("request body must not exceed %d bytes", maxBytes))
+				return
+			}
+			problem.Error(w, r, http.StatusBadRequest, problem.CodeInvalidBody, "could not read request body")
+			return
+		}
+
+		fields, err := validation.Validate(route.Schema, body)
+		if err != nil {
+			logging.FromContext(r.Context()).Error("error validating request body", "error", err, "schema", route.Schema)
+			problem.Error(w, r, http.StatusInternalServerError, problem.CodeInternal, "could not validate request body")
+			return
+		}
+		if len(fields) > 0 {
+			p := problem.New(http.StatusUnprocessableEntity, problem.CodeValidation, "request body failed validation")
+			p.Errors = fields
+			problem.Write(w, r, p)
+			return
+		}
+
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		r.ContentLength = int64(len(body))
+		next.ServeHTTP(w, r)
+	})
+}
+
+// readCloser reads a body through a buffer while closing the original
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+func allowed(mediaType string, contentTypes []string) bool {
+	for _, ct := range contentTypes {
+		if strings.EqualFold(mediaType, ct) {
+			return true
+		}
+	}
+	return false
+}
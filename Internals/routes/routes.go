@@ -2,8 +2,12 @@ package routes
 
 import (
 	"net/http"
+	"time"
 
-	"github.com/go-chi/chi"
+	"github.com/Thedrogon/blogbish/Internals/health"
+	"github.com/Thedrogon/blogbish/Internals/metrics"
+	"github.com/Thedrogon/blogbish/Internals/openapi"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
 func (s *Server) SetupRoutes() {
@@ -15,46 +19,27 @@ func (s *Server) SetupRoutes() {
 		w.Write([]byte("OK"))
 	})
 
-	// Auth Service Routes
-	s.router.Route("/auth", func(r chi.Router) {
-		r.Post("/register", s.proxyRequest("http://auth  -service:8080/register"))
-		r.Post("/login", s.proxyRequest("http://auth-service:8080/login"))
-		r.Post("/refresh", s.proxyRequest("http://auth-service:8080/refresh"))
-		r.Post("/logout", s.proxyRequest("http://auth-service:8080/logout"))
-	})
-
-	// Post Service Routes
-	s.router.Route("/posts", func(r chi.Router) {
-		r.Get("/", s.proxyRequest("http://post-service:8081/posts"))
-		r.Post("/", s.proxyRequest("http://post-service:8081/posts"))
-		r.Get("/{id}", s.proxyRequest("http://post-service:8081/posts/{id}"))
-		r.Put("/{id}", s.proxyRequest("http://post-service:8081/posts/{id}"))
-		r.Delete("/{id}", s.proxyRequest("http://post-service:8081/posts/{id}"))
-	})
-
-	// Comment Service Routes
-	s.router.Route("/comments", func(r chi.Router) {
-		r.Get("/", s.proxyRequest("http://comment-service:8083/comments"))
-		r.Post("/", s.proxyRequest("http://comment-service:8083/comments"))
-		r.Get("/{id}", s.proxyRequest("http://comment-service:8083/comments/{id}"))
-		r.Put("/{id}", s.proxyRequest("http://comment-service:8083/comments/{id}"))
-		r.Delete("/{id}", s.proxyRequest("http://comment-service:8083/comments/{id}"))
-		r.Post("/{id}/like", s.proxyRequest("http://comment-service:8083/comments/{id}/like"))
-		r.Post("/{id}/report", s.proxyRequest("http://comment-service:8083/comments/{id}/report"))
-		r.Put("/{id}/moderate", s.proxyRequest("http://comment-service:8083/comments/{id}/moderate"))
-		r.Get("/ws", s.proxyRequest("http://comment-service:8083/ws"))
-	})
-
-	// Media Service Routes
-	s.router.Route("/media", func(r chi.Router) {
-		r.Post("/upload", s.proxyRequest("http://media-service:8082/upload"))
-		r.Get("/{id}", s.proxyRequest("http://media-service:8082/media/{id}"))
-		r.Delete("/{id}", s.proxyRequest("http://media-service:8082/media/{id}"))
-	})
-
-	// Search Service Routes
-	s.router.Route("/search", func(r chi.Router) {
-		r.Get("/posts", s.proxyRequest("http://search-service:8084/search/posts"))
-		r.Get("/users", s.proxyRequest("http://search-service:8084/search/users"))
-	})
-}
\ No newline at end of file
+	// Kubernetes-style probes; the gateway has no dependencies of its own, so
+	// readiness only fails while shutting down
+	s.router.Get("/healthz", health.Liveness)
+	s.router.Get("/readyz", s.checker.Readiness)
+
+	// Prometheus metrics
+	s.router.Handle("/metrics", metrics.Handler())
+
+	// API documentation merged from the services' OpenAPI documents
+	docs := openapi.NewAggregator(apiSources, V2.Mappings(), &http.Client{
+		Timeout:   10 * time.Second,
+		Transport: otelhttp.NewTransport(http.DefaultTransport),
+	}, time.Minute)
+	s.router.Handle("/openapi.json", docs)
+	s.router.Get("/docs", openapi.SwaggerUI("/openapi.json"))
+
+	// Versioned API routes
+	for _, v := range Versions {
+		s.router.Route("/"+v.Name, s.mountVersion(v, v.Name))
+	}
+
+	// Unversioned paths are kept as an alias of v1 for existing clients
+	s.router.Group(s.mountVersion(V1, "unversioned"))
+}
@@ -0,0 +1,145 @@
+package routes
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Thedrogon/blogbish/Internals/health"
+	"github.com/Thedrogon/blogbish/Internals/logging"
+	"github.com/Thedrogon/blogbish/Internals/metrics"
+	"github.com/Thedrogon/blogbish/Internals/problem"
+	"github.com/Thedrogon/blogbish/Internals/tracing"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/cors"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+// Server is the API gateway, proxying versioned routes to the services
+type Server struct {
+	router  *chi.Mux
+	checker *health.Checker
+}
+
+// NewServer creates the gateway router with its middleware; SetupRoutes
+// registers the routes
+func NewServer(logger *slog.Logger, checker *health.Checker) *Server {
+	r := chi.NewRouter()
+
+	// Basic middleware
+	r.Use(logging.RequestID)
+	r.Use(logging.AccessLog(logger))
+	r.Use(tracing.RouteSpanName)
+	r.Use(metrics.Middleware)
+	r.Use(middleware.Recoverer)
+	r.Use(middleware.RealIP)
+	r.Use(middleware.Timeout(60 * time.Second))
+
+	// CORS configuration
+	r.Use(cors.Handler(cors.Options{
+		AllowedOrigins:   []string{"*"},
+		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", logging.RequestIDHeader},
+		ExposedHeaders:   []string{"Link", "X-Total-Count", "X-Next-Cursor", "Deprecation", "Sunset", logging.RequestIDHeader},
+		AllowCredentials: true,
+		MaxAge:           300,
+	}))
+
+	return &Server{
+		router:  r,
+		checker: checker,
+	}
+}
+
+// ServeHTTP routes r through the gateway
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.router.ServeHTTP(w, r)
+}
+
+func (s *Server) proxyRequest(target string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := logging.FromContext(r.Context())
+
+		// Create a new request
+		proxyReq, err := http.NewRequestWithContext(r.Context(), r.Method, expandParams(target, r), r.Body)
+		if err != nil {
+			log.Error("error creating proxy request", "error", err, "target", target)
+			problem.Error(w, r, http.StatusInternalServerError, problem.CodeInternal, "could not create upstream request")
+			return
+		}
+		proxyReq.ContentLength = r.ContentLength
+
+		// Copy headers
+		for name, values := range r.Header {
+			for _, value := range values {
+				proxyReq.Header.Add(name, value)
+			}
+		}
+
+		// Forward the request ID so upstream logs can be correlated
+		proxyReq.Header.Set(logging.RequestIDHeader, logging.RequestIDFromContext(r.Context()))
+
+		// Copy URL parameters
+		proxyReq.URL.RawQuery = r.URL.RawQuery
+
+		// Send the request, injecting the W3C trace context for the upstream service
+		client := &http.Client{
+			Timeout:   time.Second * 30,
+			Transport: otelhttp.NewTransport(http.DefaultTransport),
+			// Redirects, such as those from renamed slugs, are for the client to follow
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		}
+		resp, err := client.Do(proxyReq)
+		if err != nil {
+			// Bodies of unknown length are only cut off by the size cap while streaming
+			var maxErr *http.MaxBytesError
+			if errors.As(err, &maxErr) {
+				problem.Error(w, r, http.StatusRequestEntityTooLarge, problem.CodeBodyTooLarge,
+					fmt.Sprintf("request body must not exceed %d bytes", maxErr.Limit))
+				return
+			}
+
+			log.Error("error sending proxy request", "error", err, "target", target)
+			problem.Error(w, r, http.StatusServiceUnavailable, problem.CodeUpstreamUnavailable, "upstream service is unavailable")
+			return
+		}
+		defer resp.Body.Close()
+
+		// Copy response headers
+		for name, values := range resp.Header {
+			for _, value := range values {
+				w.Header().Add(name, value)
+			}
+		}
+
+		// Copy status code
+		w.WriteHeader(resp.StatusCode)
+
+		// Copy response body using io.Copy with MaxBytesReader
+		limitedReader := http.MaxBytesReader(w, resp.Body, 32<<20)
+		if _, err := io.Copy(w, limitedReader); err != nil {
+			log.Warn("error copying response body", "error", err, "target", target)
+		}
+	}
+}
+
+// expandParams fills {param} placeholders in target with the URL parameters
+// chi matched on the incoming request
+func expandParams(target string, r *http.Request) string {
+	rctx := chi.RouteContext(r.Context())
+	if rctx == nil {
+		return target
+	}
+	for i, key := range rctx.URLParams.Keys {
+		target = strings.ReplaceAll(target, "{"+key+"}", url.PathEscape(rctx.URLParams.Values[i]))
+	}
+	return target
+}
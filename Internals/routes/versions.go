@@ -0,0 +1,275 @@
+package routes
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Thedrogon/blogbish/Internals/metrics"
+	"github.com/Thedrogon/blogbish/Internals/openapi"
+	"github.com/Thedrogon/blogbish/Internals/problem"
+	"github.com/go-chi/chi/v5"
+)
+
+// Upstream service base URLs
+const (
+	authService    = "http://auth-service:8080"
+	postService    = "http://post-service:8081"
+	mediaService   = "http://media-service:8082"
+	commentService = "http://comment-service:8083"
+	searchService  = "http://search-service:8084"
+)
+
+// Route maps a gateway path to an upstream URL. Path parameters such as {id}
+// in Upstream are filled in from the matched gateway path. A route without an
+// upstream answers 410 Gone.
+type Route struct {
+	Method   string
+	Pattern  string
+	Upstream string
+
+	// Body limits enforced before the request is proxied; see body.go
+	MaxBodyBytes int64
+	ContentTypes []string
+	Schema       string
+}
+
+func get(pattern, upstream string) Route {
+	return Route{Method: http.MethodGet, Pattern: pattern, Upstream: upstream}
+}
+
+func del(pattern, upstream string) Route {
+	return Route{Method: http.MethodDelete, Pattern: pattern, Upstream: upstream}
+}
+
+func post(pattern, upstream string) Route {
+	return Route{Method: http.MethodPost, Pattern: pattern, Upstream: upstream}
+}
+
+func put(pattern, upstream string) Route {
+	return Route{Method: http.MethodPut, Pattern: pattern, Upstream: upstream}
+}
+
+// gone keeps a legacy path whose upstream endpoint no longer exists, so
+// clients learn it was removed rather than mistyped
+func gone(method, pattern string) Route {
+	return Route{Method: method, Pattern: pattern}
+}
+
+// WithSchema validates JSON request bodies against the named schema
+func (rt Route) WithSchema(name string) Route {
+	rt.Schema = name
+	return rt
+}
+
+// WithBody overrides the default body size cap and allowed content types
+func (rt Route) WithBody(maxBytes int64, contentTypes ...string) Route {
+	rt.MaxBodyBytes = maxBytes
+	rt.ContentTypes = contentTypes
+	return rt
+}
+
+// Version is a public API version with its own upstream mapping
+type Version struct {
+	Name string
+
+	// Deprecated is when the version is retired, announced ahead of time;
+	// zero while it is supported
+	Deprecated time.Time
+
+	// Sunset is when the version will stop being served
+	Sunset time.Time
+
+	// Successor is the version clients should migrate to
+	Successor string
+
+	Routes []Route
+}
+
+// IsDeprecated reports whether the version has a retirement date
+func (v Version) IsDeprecated() bool {
+	return !v.Deprecated.IsZero()
+}
+
+var commentRoutes = []Route{
+	get("/comments", commentService+"/comments"),
+	post("/comments", commentService+"/comments").WithSchema("comment-create"),
+	get("/comments/ws", commentService+"/ws"),
+	get("/comments/{id}", commentService+"/comments/{id}"),
+	put("/comments/{id}", commentService+"/comments/{id}").WithSchema("comment-update"),
+	del("/comments/{id}", commentService+"/comments/{id}"),
+	post("/comments/{id}/like", commentService+"/comments/{id}/like"),
+	post("/comments/{id}/report", commentService+"/comments/{id}/report"),
+	put("/comments/{id}/moderate", commentService+"/comments/{id}/moderate"),
+}
+
+var postRoutes = []Route{
+	get("/posts", postService+"/posts"),
+	post("/posts", postService+"/posts").WithSchema("post-create"),
+	get("/posts/{id}", postService+"/posts/{id}"),
+	put("/posts/{id}", postService+"/posts/{id}").WithSchema("post-update"),
+	del("/posts/{id}", postService+"/posts/{id}"),
+}
+
+// V1 is the original gateway API. It keeps the legacy paths so existing
+// clients continue to work until the sunset date; those whose endpoints were
+// removed from the services answer 410 Gone. It is retired at the start of
+// 2027 and served for six months after.
+var V1 = Version{
+	Name:       "v1",
+	Deprecated: time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC),
+	Sunset:     time.Date(2027, time.July, 1, 0, 0, 0, 0, time.UTC),
+	Successor:  "v2",
+	Routes: concat(
+		[]Route{
+			post("/auth/register", authService+"/auth/register").WithSchema("auth-register"),
+			post("/auth/login", authService+"/auth/login").WithSchema("auth-login"),
+			gone(http.MethodPost, "/auth/refresh"),
+			gone(http.MethodPost, "/auth/logout"),
+		},
+		postRoutes,
+		commentRoutes,
+		[]Route{
+			post("/media/upload", mediaService+"/api/v1/media/upload").WithBody(maxUploadBytes, "multipart/form-data"),
+			get("/media/{id}", mediaService+"/api/v1/media/{id}"),
+			del("/media/{id}", mediaService+"/api/v1/media/{id}"),
+			gone(http.MethodGet, "/search/posts"),
+			gone(http.MethodGet, "/search/users"),
+		},
+	),
+}
+
+// V2 mirrors the routes the services actually expose
+var V2 = Version{
+	Name: "v2",
+	Routes: concat(
+		[]Route{
+			post("/auth/register", authService+"/auth/register").WithSchema("auth-register"),
+			post("/auth/login", authService+"/auth/login").WithSchema("auth-login"),
+			get("/auth/me", authService+"/auth/me"),
+		},
+		postRoutes,
+		[]Route{
+			get("/posts/scheduled", postService+"/posts/scheduled"),
+			get("/posts/feed", postService+"/posts/feed"),
+			get("/authors/{id}/feed", postService+"/authors/{id}/feed"),
+			get("/sitemap.xml", postService+"/sitemap.xml"),
+			get("/sitemap-{name}.xml", postService+"/sitemap-{name}.xml"),
+			get("/posts/{id}/revisions", postService+"/posts/{id}/revisions"),
+			get("/posts/{id}/revisions/diff", postService+"/posts/{id}/revisions/diff"),
+			get("/posts/{id}/revisions/{revision}", postService+"/posts/{id}/revisions/{revision}"),
+			post("/posts/{id}/revisions/{revision}/restore", postService+"/posts/{id}/revisions/{revision}/restore"),
+			get("/categories", postService+"/categories"),
+			post("/categories", postService+"/categories").WithSchema("category-create"),
+			get("/categories/{slug}", postService+"/categories/{slug}"),
+			get("/categories/{slug}/feed", postService+"/categories/{slug}/feed"),
+			put("/categories/{slug}", postService+"/categories/{slug}").WithSchema("category-update"),
+			del("/categories/{slug}", postService+"/categories/{slug}"),
+			post("/categories/{slug}/move", postService+"/categories/{slug}/move").WithSchema("category-move"),
+			get("/tags", postService+"/tags"),
+			get("/tag-cloud", postService+"/tag-cloud"),
+			get("/tags/{tag}", postService+"/tags/{tag}"),
+			put("/tags/{tag}", postService+"/tags/{tag}").WithSchema("tag-update"),
+			get("/tags/{tag}/feed", postService+"/tags/{tag}/feed"),
+			post("/tags/{tag}/aliases", postService+"/tags/{tag}/aliases").WithSchema("tag-alias"),
+			del("/tags/{tag}/aliases/{alias}", postService+"/tags/{tag}/aliases/{alias}"),
+			post("/tags/{tag}/merge", postService+"/tags/{tag}/merge").WithSchema("tag-merge"),
+			get("/series", postService+"/series"),
+			post("/series", postService+"/series").WithSchema("series-create"),
+			get("/series/{slug}", postService+"/series/{slug}"),
+			put("/series/{slug}", postService+"/series/{slug}").WithSchema("series-update"),
+			del("/series/{slug}", postService+"/series/{slug}"),
+			post("/series/{slug}/posts", postService+"/series/{slug}/posts").WithSchema("series-post-add"),
+			put("/series/{slug}/posts", postService+"/series/{slug}/posts").WithSchema("series-reorder"),
+			del("/series/{slug}/posts/{post}", postService+"/series/{slug}/posts/{post}"),
+		},
+		commentRoutes,
+		[]Route{
+			post("/media", mediaService+"/api/v1/media/upload").WithBody(maxUploadBytes, "multipart/form-data"),
+			get("/media/{id}", mediaService+"/api/v1/media/{id}"),
+			get("/media/{id}/download", mediaService+"/api/v1/media/{id}/download"),
+			put("/media/{id}/metadata", mediaService+"/api/v1/media/{id}/metadata").WithSchema("media-metadata"),
+			del("/media/{id}", mediaService+"/api/v1/media/{id}"),
+			post("/search", searchService+"/search").WithSchema("search"),
+			post("/search/suggest", searchService+"/suggest").WithSchema("search-suggest"),
+		},
+	),
+}
+
+// Versions lists every version the gateway serves, oldest first
+var Versions = []Version{V1, V2}
+
+// apiSources are the services whose OpenAPI documents make up the gateway's
+var apiSources = []openapi.Source{
+	{Name: "auth", BaseURL: authService},
+	{Name: "post", BaseURL: postService},
+	{Name: "comment", BaseURL: commentService},
+	{Name: "media", BaseURL: mediaService},
+	{Name: "search", BaseURL: searchService},
+}
+
+// Mappings lists the upstream operations exposed by v and their gateway paths
+func (v Version) Mappings() []openapi.Mapping {
+	mappings := make([]openapi.Mapping, 0, len(v.Routes))
+	for _, route := range v.Routes {
+		if route.Upstream == "" {
+			continue
+		}
+		mappings = append(mappings, openapi.Mapping{
+			Method:   route.Method,
+			Path:     "/" + v.Name + route.Pattern,
+			Upstream: route.Upstream,
+		})
+	}
+	return mappings
+}
+
+// mountVersion registers the routes of v on r, labelling usage metrics with
+// label so unversioned legacy traffic can be told apart from /v1 traffic
+func (s *Server) mountVersion(v Version, label string) func(r chi.Router) {
+	return func(r chi.Router) {
+		r.Use(versionHeaders(v, label))
+		for _, route := range v.Routes {
+			if route.Upstream == "" {
+				r.MethodFunc(route.Method, route.Pattern, removed)
+				continue
+			}
+			r.Method(route.Method, route.Pattern, enforceBody(route, s.proxyRequest(route.Upstream)))
+		}
+	}
+}
+
+// removed answers requests for routes without an upstream
+func removed(w http.ResponseWriter, r *http.Request) {
+	problem.Error(w, r, http.StatusGone, problem.CodeGone, "this endpoint has been removed; see the successor version")
+}
+
+// versionHeaders advertises retirement of deprecated versions using the
+// Deprecation (RFC 9745) and Sunset (RFC 8594) headers and records usage
+func versionHeaders(v Version, label string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			metrics.VersionRequest(label, v.IsDeprecated())
+
+			if v.IsDeprecated() {
+				w.Header().Set("Deprecation", fmt.Sprintf("@%d", v.Deprecated.Unix()))
+				if !v.Sunset.IsZero() {
+					w.Header().Set("Sunset", v.Sunset.UTC().Format(http.TimeFormat))
+				}
+				if v.Successor != "" {
+					w.Header().Add("Link", fmt.Sprintf("</%s>; rel=\"successor-version\"", v.Successor))
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func concat(groups ...[]Route) []Route {
+	var routes []Route
+	for _, g := range groups {
+		routes = append(routes, g...)
+	}
+	return routes
+}
@@ -0,0 +1,89 @@
+// Package rules validates request structs by their validate tags, reporting
+// failures as problem field errors
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/Thedrogon/blogbish/Internals/problem"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+
+	// Report fields by their JSON name so clients can match them to their input
+	v.RegisterTagNameFunc(func(f reflect.StructField) string {
+		name := strings.SplitN(f.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return f.Name
+		}
+		return name
+	})
+
+	return v
+}
+
+// Struct evaluates the validate tags on s and returns one error per failing
+// field, or nil when s is valid
+func Struct(s interface{}) []problem.FieldError {
+	err := validate.Struct(s)
+	if err == nil {
+		return nil
+	}
+
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return []problem.FieldError{{Message: err.Error()}}
+	}
+
+	fields := make([]problem.FieldError, 0, len(verrs))
+	for _, fe := range verrs {
+		fields = append(fields, problem.FieldError{
+			Field:   fe.Field(),
+			Rule:    fe.Tag(),
+			Message: Message(fe),
+		})
+	}
+	return fields
+}
+
+// Message describes the rule fe failed, e.g. "must be at most 200 characters"
+func Message(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "email":
+		return "must be a valid email address"
+	case "url":
+		return "must be an absolute URL"
+	case "min":
+		if fe.Kind() == reflect.String {
+			return fmt.Sprintf("must be at least %s characters", fe.Param())
+		}
+		return fmt.Sprintf("must be at least %s", fe.Param())
+	case "max":
+		if fe.Kind() == reflect.String {
+			return fmt.Sprintf("must be at most %s characters", fe.Param())
+		}
+		return fmt.Sprintf("must be at most %s", fe.Param())
+	case "required_if":
+		// Param is "<Field> <value>", using the Go field name
+		if field, value, ok := strings.Cut(fe.Param(), " "); ok {
+			return fmt.Sprintf("is required when %s is %s", strings.ToLower(field), value)
+		}
+		return "is required"
+	case "oneof":
+		return fmt.Sprintf("must be one of: %s", strings.ReplaceAll(fe.Param(), " ", ", "))
+	default:
+		return fmt.Sprintf("failed the %q rule", fe.Tag())
+	}
+}
@@ -0,0 +1,100 @@
+package validation
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/Thedrogon/blogbish/Internals/problem"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+//go:embed schemas/*.json
+var schemaFS embed.FS
+
+// ErrUnknownSchema is returned when a route refers to a schema that was not embedded
+var ErrUnknownSchema = errors.New("unknown schema")
+
+var schemas = mustCompile()
+
+// Has reports whether a schema with the given name exists
+func Has(name string) bool {
+	_, ok := schemas[name]
+	return ok
+}
+
+// Validate checks body against the named schema. It returns the offending
+// fields when the body is invalid, or an error when it could not be checked.
+func Validate(name string, body []byte) ([]problem.FieldError, error) {
+	schema, ok := schemas[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownSchema, name)
+	}
+
+	var doc interface{}
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	if err := dec.Decode(&doc); err != nil {
+		return []problem.FieldError{{Message: "request body is not valid JSON"}}, nil
+	}
+
+	err := schema.Validate(doc)
+	if err == nil {
+		return nil, nil
+	}
+
+	var verr *jsonschema.ValidationError
+	if !errors.As(err, &verr) {
+		return nil, err
+	}
+
+	var fields []problem.FieldError
+	collect(verr, &fields)
+	return fields, nil
+}
+
+// collect flattens the validation error tree into its leaf causes
+func collect(err *jsonschema.ValidationError, fields *[]problem.FieldError) {
+	if len(err.Causes) == 0 {
+		*fields = append(*fields, problem.FieldError{
+			Field:   strings.ReplaceAll(strings.TrimPrefix(err.InstanceLocation, "/"), "/", "."),
+			Message: err.Message,
+		})
+		return
+	}
+	for _, cause := range err.Causes {
+		collect(cause, fields)
+	}
+}
+
+func mustCompile() map[string]*jsonschema.Schema {
+	entries, err := schemaFS.ReadDir("schemas")
+	if err != nil {
+		panic(err)
+	}
+
+	compiler := jsonschema.NewCompiler()
+	compiler.Draft = jsonschema.Draft2020
+	compiler.AssertFormat = true
+
+	compiled := make(map[string]*jsonschema.Schema, len(entries))
+	for _, entry := range entries {
+		file := path.Join("schemas", entry.Name())
+		data, err := schemaFS.ReadFile(file)
+		if err != nil {
+			panic(err)
+		}
+		if err := compiler.AddResource(file, bytes.NewReader(data)); err != nil {
+			panic(fmt.Sprintf("invalid schema %s: %v", file, err))
+		}
+
+		name := strings.TrimSuffix(entry.Name(), ".json")
+		compiled[name] = compiler.MustCompile(file)
+	}
+
+	return compiled
+}
@@ -1,32 +1,58 @@
 package main
 
 import (
-	"database/sql"
+	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 	"os"
 	"time"
 
+	"github.com/Thedrogon/blogbish/Internals/health"
+	"github.com/Thedrogon/blogbish/Internals/logging"
+	"github.com/Thedrogon/blogbish/Internals/metrics"
+	"github.com/Thedrogon/blogbish/Internals/openapi"
+	"github.com/Thedrogon/blogbish/Internals/tracing"
 	"github.com/Thedrogon/blogbish/auth-service/internal/config"
 	"github.com/Thedrogon/blogbish/auth-service/internal/handlers"
 	"github.com/Thedrogon/blogbish/auth-service/internal/repository"
 	"github.com/Thedrogon/blogbish/auth-service/internal/service"
+	"github.com/XSAM/otelsql"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
 	_ "github.com/lib/pq"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 )
 
 func main() {
+	logger := logging.New("auth-service")
+	if err := run(logger); err != nil {
+		logger.Error("service stopped", "error", err)
+		os.Exit(1)
+	}
+}
+
+// run starts the service and blocks until it has shut down. It returns
+// rather than exiting so deferred cleanup, such as flushing traces, still runs.
+func run(logger *slog.Logger) error {
+
 	// Load configuration
 	cfg, err := config.LoadConfig("config/config.json")
 	if err != nil {
-		log.Fatalf("Failed to load config: %v", err)
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Initialize tracing
+	shutdownTracing, err := tracing.Init(context.Background(), "auth-service")
+	if err != nil {
+		return fmt.Errorf("failed to initialize tracing: %w", err)
 	}
+	defer shutdownTracing(context.Background())
 
 	// Initialize database connection
-	db, err := sql.Open("postgres", fmt.Sprintf(
+	db, err := otelsql.Open("postgres", fmt.Sprintf(
 		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
 		cfg.Database.Host,
 		cfg.Database.Port,
@@ -34,11 +60,12 @@ func main() {
 		cfg.Database.Password,
 		cfg.Database.DBName,
 		cfg.Database.SSLMode,
-	))
+	), otelsql.WithAttributes(semconv.DBSystemPostgreSQL))
 	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+		return fmt.Errorf("failed to connect to database: %w", err)
 	}
 	defer db.Close()
+	metrics.RegisterDBStats(db, "blogbish")
 
 	// Initialize repositories
 	userRepo := repository.NewPostgresUserRepository(db)
@@ -50,6 +77,10 @@ func main() {
 		time.Duration(cfg.JWT.ExpiresIn)*time.Hour,
 	)
 
+	// Readiness checks
+	checker := health.NewChecker(2 * time.Second)
+	checker.Add("postgres", db.PingContext)
+
 	// Initialize handlers
 	authHandler := handlers.NewAuthHandler(authService)
 
@@ -57,17 +88,25 @@ func main() {
 	r := chi.NewRouter()
 
 	// Middleware
-	r.Use(middleware.Logger)
+	r.Use(logging.RequestID)
+	r.Use(logging.AccessLog(logger))
+	r.Use(tracing.RouteSpanName)
+	r.Use(metrics.Middleware)
 	r.Use(middleware.Recoverer)
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{"*"},
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type"},
-		ExposedHeaders:   []string{"Link"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", logging.RequestIDHeader},
+		ExposedHeaders:   []string{"Link", logging.RequestIDHeader},
 		AllowCredentials: true,
 		MaxAge:           300,
 	}))
 
+	r.Handle("/metrics", metrics.Handler())
+	r.Get("/healthz", health.Liveness)
+	r.Get("/readyz", checker.Readiness)
+	r.Get("/openapi.json", openapi.Handler(handlers.OpenAPI()))
+
 	// Public routes
 	r.Group(func(r chi.Router) {
 		r.Post("/auth/register", authHandler.Register())
@@ -86,8 +125,13 @@ func main() {
 		port = cfg.Server.Port
 	}
 
-	log.Printf("Server starting on port %s", port)
-	if err := http.ListenAndServe(":"+port, r); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+	srv := &http.Server{
+		Addr:         ":" + port,
+		Handler:      otelhttp.NewHandler(r, "auth-service"),
+		IdleTimeout:  time.Minute,
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 30 * time.Second,
 	}
+
+	return health.Serve(srv, checker, logger)
 }
@@ -49,5 +49,10 @@ func LoadConfig(path string) (*Config, error) {
 		return nil, err
 	}
 
+	// The secret is shared with every service that verifies tokens
+	if secret := os.Getenv("JWT_SECRET"); secret != "" {
+		config.JWT.SecretKey = secret
+	}
+
 	return &config, nil
 }
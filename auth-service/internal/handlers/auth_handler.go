@@ -1,11 +1,12 @@
 package handlers
 
 import (
-	"context"
 	"encoding/json"
 	"net/http"
-	"strings"
 
+	"github.com/Thedrogon/blogbish/Internals/auth"
+	"github.com/Thedrogon/blogbish/Internals/problem"
+	"github.com/Thedrogon/blogbish/Internals/validation/rules"
 	"github.com/Thedrogon/blogbish/auth-service/internal/models"
 	"github.com/Thedrogon/blogbish/auth-service/internal/service"
 )
@@ -25,17 +26,17 @@ func (h *AuthHandler) Register() http.HandlerFunc {
 		var input models.UserCreate
 
 		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			problem.Error(w, r, http.StatusBadRequest, problem.CodeInvalidBody, "request body must be valid JSON")
+			return
+		}
+		if fields := rules.Struct(&input); fields != nil {
+			problem.Invalid(w, r, fields)
 			return
 		}
 
 		user, err := h.authService.Register(r.Context(), &input)
 		if err != nil {
-			if err == service.ErrUserExists {
-				http.Error(w, err.Error(), http.StatusConflict)
-				return
-			}
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			problem.WriteError(w, r, problems, err)
 			return
 		}
 
@@ -48,17 +49,17 @@ func (h *AuthHandler) Login() http.HandlerFunc {
 		var input models.UserLogin
 
 		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			problem.Error(w, r, http.StatusBadRequest, problem.CodeInvalidBody, "request body must be valid JSON")
+			return
+		}
+		if fields := rules.Struct(&input); fields != nil {
+			problem.Invalid(w, r, fields)
 			return
 		}
 
 		token, err := h.authService.Login(r.Context(), &input)
 		if err != nil {
-			if err == service.ErrInvalidCredentials {
-				http.Error(w, err.Error(), http.StatusUnauthorized)
-				return
-			}
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			problem.WriteError(w, r, problems, err)
 			return
 		}
 
@@ -68,15 +69,15 @@ func (h *AuthHandler) Login() http.HandlerFunc {
 
 func (h *AuthHandler) GetMe() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		claims, ok := r.Context().Value("user").(*service.TokenClaims)
+		claims, ok := auth.ClaimsFromContext(r.Context())
 		if !ok {
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			problem.Error(w, r, http.StatusUnauthorized, problem.CodeUnauthorized, "authentication is required")
 			return
 		}
 
 		user, err := h.authService.GetUserByID(r.Context(), claims.UserID)
 		if err != nil {
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			problem.WriteError(w, r, problems, err)
 			return
 		}
 
@@ -94,27 +95,23 @@ func respondJSON(w http.ResponseWriter, status int, data interface{}) {
 func AuthMiddleware(authService *service.AuthService) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			authHeader := r.Header.Get("Authorization")
-			if authHeader == "" {
-				http.Error(w, "Authorization header is required", http.StatusUnauthorized)
+			token, ok, err := auth.BearerToken(r)
+			if !ok {
+				problem.Error(w, r, http.StatusUnauthorized, problem.CodeUnauthorized, "authorization header is required")
 				return
 			}
-
-			bearerToken := strings.Split(authHeader, " ")
-			if len(bearerToken) != 2 || strings.ToLower(bearerToken[0]) != "bearer" {
-				http.Error(w, "Invalid authorization header format", http.StatusUnauthorized)
+			if err != nil {
+				problem.Error(w, r, http.StatusUnauthorized, problem.CodeUnauthorized, err.Error())
 				return
 			}
 
-			claims, err := authService.ValidateToken(bearerToken[1])
+			claims, err := authService.ValidateToken(token)
 			if err != nil {
-				http.Error(w, "Invalid token", http.StatusUnauthorized)
+				problem.Error(w, r, http.StatusUnauthorized, problem.CodeUnauthorized, "token is invalid or expired")
 				return
 			}
 
-			ctx := r.Context()
-			ctx = context.WithValue(ctx, "user", claims)
-			next.ServeHTTP(w, r.WithContext(ctx))
+			next.ServeHTTP(w, r.WithContext(auth.WithClaims(r.Context(), claims)))
 		})
 	}
 }
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/Thedrogon/blogbish/Internals/openapi"
+	"github.com/Thedrogon/blogbish/Internals/problem"
+	"github.com/Thedrogon/blogbish/auth-service/internal/models"
+)
+
+// OpenAPI describes the auth service API
+func OpenAPI() *openapi.Document {
+	b := openapi.NewBuilder("Auth Service", "1.0.0", "User registration, login and JWT issuance.")
+	fail := func(description string) openapi.Response { return b.Fails(description, problem.Problem{}) }
+
+	token := &openapi.Schema{
+		Type:       "object",
+		Properties: map[string]*openapi.Schema{"token": {Type: "string", Description: "JWT to send as a Bearer token"}},
+		Required:   []string{"token"},
+	}
+
+	b.Add(http.MethodPost, "/auth/register", openapi.Operation{
+		OperationID: "register",
+		Summary:     "Register a new user",
+		Tags:        []string{"auth"},
+		RequestBody: openapi.JSON(b.Ref(models.UserCreate{})),
+		Responses: map[string]openapi.Response{
+			"201": openapi.Returns("User created", b.Ref(models.UserResponse{})),
+			"400": fail("Malformed request body"),
+			"409": fail("Email or username already taken"),
+			"422": fail("Validation failed"),
+		},
+	})
+
+	b.Add(http.MethodPost, "/auth/login", openapi.Operation{
+		OperationID: "login",
+		Summary:     "Exchange credentials for a JWT",
+		Tags:        []string{"auth"},
+		RequestBody: openapi.JSON(b.Ref(models.UserLogin{})),
+		Responses: map[string]openapi.Response{
+			"200": openapi.Returns("Authenticated", token),
+			"400": fail("Malformed request body"),
+			"401": fail("Invalid credentials"),
+			"422": fail("Validation failed"),
+		},
+	})
+
+	b.Add(http.MethodGet, "/auth/me", openapi.Operation{
+		OperationID: "getCurrentUser",
+		Summary:     "Get the authenticated user",
+		Tags:        []string{"auth"},
+		Security:    openapi.BearerAuth,
+		Responses: map[string]openapi.Response{
+			"200": openapi.Returns("Current user", b.Ref(models.UserResponse{})),
+			"401": fail("Missing or invalid token"),
+		},
+	})
+
+	return b.Document()
+}
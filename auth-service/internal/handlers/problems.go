@@ -0,0 +1,23 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/Thedrogon/blogbish/Internals/problem"
+	"github.com/Thedrogon/blogbish/auth-service/internal/repository"
+	"github.com/Thedrogon/blogbish/auth-service/internal/service"
+)
+
+// Domain error codes clients can match on, besides the shared ones in problem
+const (
+	CodeUserExists         = "user_exists"
+	CodeInvalidCredentials = "invalid_credentials"
+)
+
+// problems maps domain errors to the problem returned to clients
+var problems = problem.Mappings{
+	{Err: service.ErrUserExists, Status: http.StatusConflict, Code: CodeUserExists, Detail: "a user with this email or username already exists"},
+	{Err: repository.ErrUserExists, Status: http.StatusConflict, Code: CodeUserExists, Detail: "a user with this email or username already exists"},
+	{Err: service.ErrInvalidCredentials, Status: http.StatusUnauthorized, Code: CodeInvalidCredentials, Detail: "invalid email or password"},
+	{Err: repository.ErrUserNotFound, Status: http.StatusNotFound, Code: problem.CodeNotFound, Detail: "user not found"},
+}
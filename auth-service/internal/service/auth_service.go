@@ -5,6 +5,7 @@ import (
 	"errors"
 	"time"
 
+	"github.com/Thedrogon/blogbish/Internals/auth"
 	"github.com/Thedrogon/blogbish/auth-service/internal/models"
 	"github.com/Thedrogon/blogbish/auth-service/internal/repository"
 	"github.com/golang-jwt/jwt/v5"
@@ -29,13 +30,6 @@ func NewAuthService(userRepo repository.UserRepository, jwtSecret string, tokenE
 	}
 }
 
-type TokenClaims struct {
-	UserID   int64  `json:"user_id"`
-	Username string `json:"username"`
-	Role     string `json:"role"`
-	jwt.RegisteredClaims
-}
-
 func (s *AuthService) Register(ctx context.Context, input *models.UserCreate) (*models.UserResponse, error) {
 	// Check if user exists
 	existingUser, err := s.userRepo.GetByEmail(ctx, input.Email)
@@ -82,7 +76,7 @@ func (s *AuthService) Login(ctx context.Context, input *models.UserLogin) (strin
 	}
 
 	// Generate JWT token
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, TokenClaims{
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, auth.Claims{
 		UserID:   user.ID,
 		Username: user.Username,
 		Role:     user.Role,
@@ -100,23 +94,8 @@ func (s *AuthService) Login(ctx context.Context, input *models.UserLogin) (strin
 	return tokenString, nil
 }
 
-func (s *AuthService) ValidateToken(tokenString string) (*TokenClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &TokenClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("unexpected signing method")
-		}
-		return []byte(s.jwtSecret), nil
-	})
-
-	if err != nil {
-		return nil, err
-	}
-
-	if claims, ok := token.Claims.(*TokenClaims); ok && token.Valid {
-		return claims, nil
-	}
-
-	return nil, errors.New("invalid token")
+func (s *AuthService) ValidateToken(tokenString string) (*auth.Claims, error) {
+	return auth.ParseToken(s.jwtSecret, tokenString)
 }
 
 func (s *AuthService) GetUserByID(ctx context.Context, id int64) (*models.UserResponse, error) {
@@ -0,0 +1,128 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TokenSource supplies the JWT sent with authenticated requests
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// refresher is implemented by token sources that can obtain a new token
+// after the API rejected the current one
+type refresher interface {
+	invalidate()
+}
+
+// StaticToken is a TokenSource that always returns the same token
+type StaticToken string
+
+func (t StaticToken) Token(context.Context) (string, error) {
+	return string(t), nil
+}
+
+// expiryLeeway renews tokens this long before they expire
+const expiryLeeway = 30 * time.Second
+
+// passwordTokenSource logs in with credentials and caches the token until
+// shortly before it expires
+type passwordTokenSource struct {
+	client   *Client
+	email    string
+	password string
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+}
+
+func (s *passwordTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && (s.expires.IsZero() || time.Until(s.expires) > expiryLeeway) {
+		return s.token, nil
+	}
+
+	token, err := s.client.Auth.Login(ctx, s.email, s.password)
+	if err != nil {
+		return "", err
+	}
+
+	s.token = token
+	s.expires = tokenExpiry(token)
+	return token, nil
+}
+
+func (s *passwordTokenSource) invalidate() {
+	s.mu.Lock()
+	s.token = ""
+	s.mu.Unlock()
+}
+
+// tokenExpiry reads the exp claim of a JWT without verifying it; the zero
+// time is returned when it cannot be determined
+func tokenExpiry(token string) time.Time {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}
+	}
+
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == 0 {
+		return time.Time{}
+	}
+	return time.Unix(claims.Exp, 0)
+}
+
+// AuthService covers registration, login and the current user
+type AuthService struct {
+	client *Client
+}
+
+// Register creates a new user account
+func (s *AuthService) Register(ctx context.Context, input *UserCreate) (*User, error) {
+	var user User
+	_, err := s.client.do(ctx, request{method: http.MethodPost, path: "/auth/register", body: input, anonymous: true}, &user)
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// Login exchanges credentials for a JWT. Clients configured with
+// WithCredentials call this automatically.
+func (s *AuthService) Login(ctx context.Context, email, password string) (string, error) {
+	var out struct {
+		Token string `json:"token"`
+	}
+	input := map[string]string{"email": email, "password": password}
+	_, err := s.client.do(ctx, request{method: http.MethodPost, path: "/auth/login", body: input, anonymous: true}, &out)
+	if err != nil {
+		return "", err
+	}
+	return out.Token, nil
+}
+
+// Me returns the authenticated user
+func (s *AuthService) Me(ctx context.Context) (*User, error) {
+	var user User
+	if _, err := s.client.do(ctx, request{method: http.MethodGet, path: "/auth/me"}, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
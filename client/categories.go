@@ -0,0 +1,80 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// CategoriesService covers post categories, which are addressed by slug
+type CategoriesService struct {
+	client *Client
+}
+
+// Create adds a category
+func (s *CategoriesService) Create(ctx context.Context, input *CategoryCreate) (*Category, error) {
+	var category Category
+	if _, err := s.client.do(ctx, request{method: http.MethodPost, path: "/categories", body: input}, &category); err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+// Get fetches a category by slug, with the path of its ancestors
+func (s *CategoriesService) Get(ctx context.Context, slug string) (*Category, error) {
+	var category Category
+	if _, err := s.client.do(ctx, request{method: http.MethodGet, path: categoryPath(slug)}, &category); err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+// Update changes the non-zero fields of input
+func (s *CategoriesService) Update(ctx context.Context, slug string, input *CategoryUpdate) (*Category, error) {
+	var category Category
+	if _, err := s.client.do(ctx, request{method: http.MethodPut, path: categoryPath(slug), body: input}, &category); err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+// Delete removes a category
+func (s *CategoriesService) Delete(ctx context.Context, slug string) error {
+	_, err := s.client.do(ctx, request{method: http.MethodDelete, path: categoryPath(slug)}, nil)
+	return err
+}
+
+// List returns every category
+func (s *CategoriesService) List(ctx context.Context) ([]Category, error) {
+	var categories []Category
+	if _, err := s.client.do(ctx, request{method: http.MethodGet, path: "/categories"}, &categories); err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
+
+// Move moves a category and its subcategories under parentID, or to the root
+// when parentID is nil
+func (s *CategoriesService) Move(ctx context.Context, slug string, parentID *int64) (*Category, error) {
+	input := map[string]*int64{"parent_id": parentID}
+
+	var category Category
+	if _, err := s.client.do(ctx, request{method: http.MethodPost, path: categoryPath(slug) + "/move", body: input}, &category); err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+// Tree returns the root categories with their subcategories nested under them
+func (s *CategoriesService) Tree(ctx context.Context) ([]*Category, error) {
+	var categories []*Category
+	query := url.Values{"tree": {"true"}}
+	if _, err := s.client.do(ctx, request{method: http.MethodGet, path: "/categories", query: query}, &categories); err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
+
+func categoryPath(slug string) string {
+	return "/categories/" + url.PathEscape(slug)
+}
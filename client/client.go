@@ -0,0 +1,260 @@
+// Package client is a typed Go client for the Blogbish API gateway.
+//
+//	c, err := client.New("http://localhost:8000",
+//		client.WithCredentials("me@example.com", "secret"))
+//
+//	post, err := c.Posts.Create(ctx, &client.PostCreate{Title: "Hello", ...})
+//
+//	it := c.Posts.List(client.PostFilter{Status: "published"})
+//	for it.Next(ctx) {
+//		fmt.Println(it.Item().Title)
+//	}
+//	if err := it.Err(); err != nil { ... }
+//
+// Requests go to the gateway's /v2 API. Tokens are obtained and refreshed
+// automatically, idempotent requests are retried on transient failures and
+// error responses are returned as *APIError.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// APIVersion is the gateway API version the client speaks
+const APIVersion = "v2"
+
+// Client talks to the Blogbish API gateway. It is safe for concurrent use.
+type Client struct {
+	baseURL    *url.URL
+	httpClient *http.Client
+	tokens     TokenSource
+	retry      RetryPolicy
+	userAgent  string
+
+	Auth       *AuthService
+	Posts      *PostsService
+	Categories *CategoriesService
+	Tags       *TagsService
+	Series     *SeriesService
+	Comments   *CommentsService
+	Media      *MediaService
+	Search     *SearchService
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithHTTPClient sets the underlying HTTP client
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) { c.httpClient = hc }
+}
+
+// WithToken authenticates every request with a fixed JWT
+func WithToken(token string) Option {
+	return func(c *Client) { c.tokens = StaticToken(token) }
+}
+
+// WithCredentials logs in with email and password on first use and logs in
+// again whenever the token is about to expire or is rejected
+func WithCredentials(email, password string) Option {
+	return func(c *Client) { c.tokens = &passwordTokenSource{client: c, email: email, password: password} }
+}
+
+// WithTokenSource authenticates requests with tokens from ts
+func WithTokenSource(ts TokenSource) Option {
+	return func(c *Client) { c.tokens = ts }
+}
+
+// WithRetryPolicy replaces the default retry policy
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(c *Client) { c.retry = p }
+}
+
+// WithUserAgent sets the User-Agent header sent with each request
+func WithUserAgent(ua string) Option {
+	return func(c *Client) { c.userAgent = ua }
+}
+
+// New creates a client for the gateway at baseURL, e.g. "http://localhost:8000"
+func New(baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(strings.TrimRight(baseURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL: %w", err)
+	}
+
+	c := &Client{
+		baseURL:    u,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		retry:      DefaultRetryPolicy,
+		userAgent:  "blogbish-go-client",
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	c.Auth = &AuthService{client: c}
+	c.Posts = &PostsService{client: c}
+	c.Categories = &CategoriesService{client: c}
+	c.Tags = &TagsService{client: c}
+	c.Series = &SeriesService{client: c}
+	c.Comments = &CommentsService{client: c}
+	c.Media = &MediaService{client: c}
+	c.Search = &SearchService{client: c}
+
+	return c, nil
+}
+
+// request describes a single API call
+type request struct {
+	method string
+	path   string
+	query  url.Values
+
+	// target, when set, is a URL from a Link header used instead of path and query
+	target string
+
+	// body is JSON-encoded unless it is an io.Reader, in which case it is
+	// sent as is with contentType and never retried
+	body        interface{}
+	contentType string
+
+	// anonymous requests never carry a token, e.g. login itself
+	anonymous bool
+
+	// idempotent marks read-only POSTs, such as searches, as safe to retry
+	idempotent bool
+}
+
+// response is a completed call whose body has been read
+type response struct {
+	*http.Response
+	body []byte
+}
+
+func (c *Client) url(req request) string {
+	if req.target != "" {
+		if u, err := c.baseURL.Parse(req.target); err == nil {
+			return u.String()
+		}
+	}
+
+	path, query := req.path, req.query
+	u := *c.baseURL
+	u.Path = u.Path + "/" + APIVersion + path
+	if len(query) > 0 {
+		u.RawQuery = query.Encode()
+	}
+	return u.String()
+}
+
+// do sends req, retrying transient failures, and decodes a successful JSON
+// response into out when out is non-nil
+func (c *Client) do(ctx context.Context, req request, out interface{}) (*response, error) {
+	resp, err := c.send(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if out != nil && len(resp.body) > 0 {
+		if err := json.Unmarshal(resp.body, out); err != nil {
+			return resp, fmt.Errorf("failed to decode %s %s response: %w", req.method, req.path, err)
+		}
+	}
+	return resp, nil
+}
+
+func (c *Client) send(ctx context.Context, req request) (*response, error) {
+	var payload []byte
+	stream, streaming := req.body.(io.Reader)
+	if req.body != nil && !streaming {
+		var err error
+		payload, err = json.Marshal(req.body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request body: %w", err)
+		}
+	}
+
+	refreshed := false
+	for attempt := 0; ; attempt++ {
+		var body io.Reader
+		switch {
+		case streaming:
+			body = stream
+		case payload != nil:
+			body = bytes.NewReader(payload)
+		}
+
+		resp, err := c.attempt(ctx, req, body)
+
+		// A rejected token is refreshed once, unless the body can't be replayed
+		if err == nil && resp.StatusCode == http.StatusUnauthorized && !req.anonymous && !refreshed && !streaming {
+			if r, ok := c.tokens.(refresher); ok {
+				r.invalidate()
+				refreshed = true
+				continue
+			}
+		}
+
+		if streaming || !c.retry.shouldRetry(req, attempt, resp, err) {
+			if err != nil {
+				return nil, err
+			}
+			if resp.StatusCode >= http.StatusBadRequest {
+				return nil, newAPIError(resp.Response, resp.body)
+			}
+			return resp, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(c.retry.delay(attempt, resp)):
+		}
+	}
+}
+
+func (c *Client) attempt(ctx context.Context, req request, body io.Reader) (*response, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, req.method, c.url(req), body)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq.Header.Set("Accept", "application/json, application/problem+json")
+	httpReq.Header.Set("User-Agent", c.userAgent)
+	if body != nil {
+		contentType := req.contentType
+		if contentType == "" {
+			contentType = "application/json"
+		}
+		httpReq.Header.Set("Content-Type", contentType)
+	}
+
+	if c.tokens != nil && !req.anonymous {
+		token, err := c.tokens.Token(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to obtain token: %w", err)
+		}
+		httpReq.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	httpResp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+
+	data, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	return &response{Response: httpResp, body: data}, nil
+}
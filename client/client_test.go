@@ -0,0 +1,177 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fastRetry retries without waiting, to keep tests quick
+var fastRetry = RetryPolicy{MaxRetries: 3}
+
+func TestSendRefreshesRejectedTokenOnce(t *testing.T) {
+	tests := []struct {
+		name string
+
+		// valid reports whether the API accepts the nth token issued
+		valid  func(n int64) bool
+		logins int64
+		status int
+		calls  int64
+	}{
+		{"expired token", func(n int64) bool { return n > 1 }, 2, http.StatusOK, 2},
+		{"still rejected", func(int64) bool { return false }, 2, http.StatusUnauthorized, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logins, calls atomic.Int64
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/v2/auth/login" {
+					fmt.Fprintf(w, `{"token":"token-%d"}`, logins.Add(1))
+					return
+				}
+
+				calls.Add(1)
+				var n int64
+				fmt.Sscanf(r.Header.Get("Authorization"), "Bearer token-%d", &n)
+				if !tt.valid(n) {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				fmt.Fprint(w, `{"id":1}`)
+			}))
+			defer srv.Close()
+
+			c, err := New(srv.URL, WithCredentials("me@example.com", "secret"), WithRetryPolicy(fastRetry))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = c.Auth.Me(context.Background())
+			if tt.status == http.StatusOK && err != nil {
+				t.Fatalf("Me() error = %v", err)
+			}
+			if tt.status != http.StatusOK && !hasStatus(err, tt.status) {
+				t.Fatalf("Me() error = %v, want status %d", err, tt.status)
+			}
+			if got := logins.Load(); got != tt.logins {
+				t.Errorf("got %d logins, want %d", got, tt.logins)
+			}
+			if got := calls.Load(); got != tt.calls {
+				t.Errorf("got %d calls, want %d", got, tt.calls)
+			}
+		})
+	}
+}
+
+func TestSendDoesNotRefreshStaticToken(t *testing.T) {
+	var calls atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	c, err := New(srv.URL, WithToken("fixed"), WithRetryPolicy(fastRetry))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.Auth.Me(context.Background()); !IsUnauthorized(err) {
+		t.Fatalf("Me() error = %v, want 401", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("got %d calls, want 1", got)
+	}
+}
+
+func TestSendNeverRetriesStreamingBodies(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+	}{
+		{"unavailable", http.StatusServiceUnavailable},
+		{"unauthorized", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logins, calls atomic.Int64
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/v2/auth/login" {
+					logins.Add(1)
+					fmt.Fprint(w, `{"token":"t"}`)
+					return
+				}
+				calls.Add(1)
+				io.Copy(io.Discard, r.Body)
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			c, err := New(srv.URL, WithCredentials("me@example.com", "secret"), WithRetryPolicy(fastRetry))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// PUT is idempotent, so only the streaming body prevents a retry
+			req := request{method: http.MethodPut, path: "/media/1", body: strings.NewReader("data"), contentType: "image/png"}
+			if _, err := c.send(context.Background(), req); !hasStatus(err, tt.status) {
+				t.Fatalf("send() error = %v, want status %d", err, tt.status)
+			}
+			if got := calls.Load(); got != 1 {
+				t.Errorf("got %d calls, want 1", got)
+			}
+			if got := logins.Load(); got != 1 {
+				t.Errorf("got %d logins, want 1", got)
+			}
+		})
+	}
+}
+
+func TestSendRetries(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		calls  int64
+		err    bool
+	}{
+		{"idempotent recovers", http.MethodGet, 3, false},
+		{"create is sent once", http.MethodPost, 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int64
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if calls.Add(1) < 3 {
+					w.Header().Set("Retry-After", "0")
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				fmt.Fprint(w, `{}`)
+			}))
+			defer srv.Close()
+
+			c, err := New(srv.URL, WithRetryPolicy(RetryPolicy{MaxRetries: 3, BaseDelay: time.Hour}))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = c.send(context.Background(), request{method: tt.method, path: "/posts", body: map[string]string{}})
+			if (err != nil) != tt.err {
+				t.Fatalf("send() error = %v, want error %v", err, tt.err)
+			}
+			if got := calls.Load(); got != tt.calls {
+				t.Errorf("got %d calls, want %d", got, tt.calls)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// CommentsService covers comments and their moderation
+type CommentsService struct {
+	client *Client
+}
+
+// Create adds a comment, or a reply when input.ParentID is set
+func (s *CommentsService) Create(ctx context.Context, input *CommentCreate) (*Comment, error) {
+	var comment Comment
+	if _, err := s.client.do(ctx, request{method: http.MethodPost, path: "/comments", body: input}, &comment); err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// Get fetches a single comment
+func (s *CommentsService) Get(ctx context.Context, id string) (*Comment, error) {
+	var comment Comment
+	if _, err := s.client.do(ctx, request{method: http.MethodGet, path: commentPath(id)}, &comment); err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// Update replaces the content of a comment
+func (s *CommentsService) Update(ctx context.Context, id, content string) (*Comment, error) {
+	var comment Comment
+	input := map[string]string{"content": content}
+	if _, err := s.client.do(ctx, request{method: http.MethodPut, path: commentPath(id), body: input}, &comment); err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// Delete removes a comment
+func (s *CommentsService) Delete(ctx context.Context, id string) error {
+	_, err := s.client.do(ctx, request{method: http.MethodDelete, path: commentPath(id)}, nil)
+	return err
+}
+
+// Like adds a like to a comment
+func (s *CommentsService) Like(ctx context.Context, id string) error {
+	_, err := s.client.do(ctx, request{method: http.MethodPost, path: commentPath(id) + "/like"}, nil)
+	return err
+}
+
+// Report flags a comment for moderation
+func (s *CommentsService) Report(ctx context.Context, id string) error {
+	_, err := s.client.do(ctx, request{method: http.MethodPost, path: commentPath(id) + "/report"}, nil)
+	return err
+}
+
+// Moderate sets the status of a comment, e.g. "hidden" or "active"
+func (s *CommentsService) Moderate(ctx context.Context, id, status string) error {
+	query := url.Values{"status": {status}}
+	_, err := s.client.do(ctx, request{method: http.MethodPut, path: commentPath(id) + "/moderate", query: query}, nil)
+	return err
+}
+
+// List returns an iterator over the comments matching filter, which may be nil
+func (s *CommentsService) List(filter *CommentFilter) *Iterator[Comment] {
+	query := url.Values{}
+	pageSize := 0
+	if filter != nil {
+		setQuery(query, "post_id", filter.PostID)
+		setQuery(query, "parent_id", filter.ParentID)
+		setQuery(query, "status", filter.Status)
+		if filter.UserID != 0 {
+			query.Set("user_id", strconv.FormatInt(filter.UserID, 10))
+		}
+		pageSize = filter.PageSize
+	}
+	return newIterator[Comment](s.client, "/comments", query, pageSize)
+}
+
+// Tree fetches every comment on a post and nests replies under their parent.
+// Replies whose parent is missing, e.g. because it was hidden, are returned
+// at the top level.
+func (s *CommentsService) Tree(ctx context.Context, postID string) ([]*Comment, error) {
+	comments, err := s.List(&CommentFilter{PostID: postID}).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]*Comment, len(comments))
+	for i := range comments {
+		comments[i].Children = nil
+		byID[comments[i].ID] = &comments[i]
+	}
+
+	var roots []*Comment
+	for i := range comments {
+		comment := &comments[i]
+		if comment.ParentID != nil {
+			if parent, ok := byID[*comment.ParentID]; ok && parent != comment {
+				parent.Children = append(parent.Children, comment)
+				continue
+			}
+		}
+		roots = append(roots, comment)
+	}
+	return roots, nil
+}
+
+func commentPath(id string) string {
+	return "/comments/" + url.PathEscape(id)
+}
@@ -0,0 +1,95 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// APIError is returned for every non-2xx response. Problem details from the
+// gateway and services are decoded into it, as are the older
+// {"error": "..."} and plain text formats.
+type APIError struct {
+	StatusCode int          `json:"status"`
+	Code       string       `json:"code"`
+	Title      string       `json:"title"`
+	Detail     string       `json:"detail"`
+	RequestID  string       `json:"request_id"`
+	Errors     []FieldError `json:"errors"`
+}
+
+// FieldError describes why a single field was rejected
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+func (e *APIError) Error() string {
+	msg := e.Detail
+	if msg == "" {
+		msg = e.Title
+	}
+	if msg == "" {
+		msg = http.StatusText(e.StatusCode)
+	}
+
+	s := fmt.Sprintf("blogbish: %d %s", e.StatusCode, msg)
+	if e.Code != "" {
+		s += " (" + e.Code + ")"
+	}
+	for _, fe := range e.Errors {
+		s += fmt.Sprintf("; %s %s", fe.Field, fe.Message)
+	}
+	return s
+}
+
+// IsNotFound reports whether err is an APIError with status 404
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsUnauthorized reports whether err is an APIError with status 401
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized)
+}
+
+// IsForbidden reports whether err is an APIError with status 403
+func IsForbidden(err error) bool {
+	return hasStatus(err, http.StatusForbidden)
+}
+
+// IsConflict reports whether err is an APIError with status 409
+func IsConflict(err error) bool {
+	return hasStatus(err, http.StatusConflict)
+}
+
+func hasStatus(err error, status int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == status
+}
+
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{}
+
+	contentType := resp.Header.Get("Content-Type")
+	switch {
+	case strings.Contains(contentType, "json"):
+		var legacy struct {
+			Error string `json:"error"`
+		}
+		if json.Unmarshal(body, apiErr) == nil && json.Unmarshal(body, &legacy) == nil && legacy.Error != "" {
+			apiErr.Detail = legacy.Error
+		}
+	default:
+		apiErr.Detail = strings.TrimSpace(string(body))
+	}
+
+	apiErr.StatusCode = resp.StatusCode
+	if apiErr.RequestID == "" {
+		apiErr.RequestID = resp.Header.Get("X-Request-ID")
+	}
+	return apiErr
+}
@@ -0,0 +1,127 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// DefaultPageSize is the number of items requested per page
+const DefaultPageSize = 50
+
+// Iterator walks every item of a paginated list, fetching pages lazily.
+// It follows Link rel="next" headers when the API sends them and falls back
+// to page numbers otherwise.
+type Iterator[T any] struct {
+	client   *Client
+	req      request
+	pageSize int
+	page     int
+
+	items []T
+	item  T
+	done  bool
+	err   error
+}
+
+func newIterator[T any](c *Client, path string, query url.Values, pageSize int) *Iterator[T] {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	if query == nil {
+		query = url.Values{}
+	}
+	return &Iterator[T]{
+		client:   c,
+		req:      request{method: http.MethodGet, path: path, query: query},
+		pageSize: pageSize,
+	}
+}
+
+// Next advances to the next item, fetching the next page when needed. It
+// returns false when the list is exhausted or an error occurred.
+func (it *Iterator[T]) Next(ctx context.Context) bool {
+	for len(it.items) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+		it.fetch(ctx)
+	}
+
+	it.item, it.items = it.items[0], it.items[1:]
+	return true
+}
+
+// Item returns the current item
+func (it *Iterator[T]) Item() T {
+	return it.item
+}
+
+// Err returns the error that stopped iteration, if any
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// All drains the iterator into a slice
+func (it *Iterator[T]) All(ctx context.Context) ([]T, error) {
+	var all []T
+	for it.Next(ctx) {
+		all = append(all, it.Item())
+	}
+	return all, it.Err()
+}
+
+func (it *Iterator[T]) fetch(ctx context.Context) {
+	if it.req.target == "" {
+		it.page++
+		it.req.query.Set("page", strconv.Itoa(it.page))
+		it.req.query.Set("page_size", strconv.Itoa(it.pageSize))
+	}
+
+	resp, err := it.client.do(ctx, it.req, nil)
+	if err != nil {
+		it.err = err
+		return
+	}
+
+	var page []T
+	if err := json.Unmarshal(resp.body, &page); err != nil {
+		it.err = fmt.Errorf("failed to decode page: %w", err)
+		return
+	}
+	it.items = page
+
+	if next := nextLink(resp.Header.Values("Link")); next != "" {
+		// Links may be relative, such as the services' "?cursor=…"
+		if u, err := resp.Request.URL.Parse(next); err == nil {
+			next = u.String()
+		}
+		it.req.target = next
+		return
+	}
+	if it.req.target != "" || len(page) < it.pageSize {
+		it.done = true
+	}
+}
+
+// nextLink returns the target of the rel="next" link in Link headers
+func nextLink(headers []string) string {
+	for _, header := range headers {
+		for _, link := range strings.Split(header, ",") {
+			target, params, ok := strings.Cut(strings.TrimSpace(link), ";")
+			if !ok {
+				continue
+			}
+			for _, param := range strings.Split(params, ";") {
+				if strings.ReplaceAll(strings.TrimSpace(param), `"`, "") == "rel=next" {
+					return strings.Trim(strings.TrimSpace(target), "<>")
+				}
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,152 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestIteratorFollowsRelativeCursorLinks(t *testing.T) {
+	pages := map[string]string{
+		"":   `[{"id":1},{"id":2}]`,
+		"c2": `[{"id":3},{"id":4}]`,
+		"c3": `[{"id":5}]`,
+	}
+	next := map[string]string{"": "c2", "c2": "c3"}
+
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		cursor := r.URL.Query().Get("cursor")
+		if cursor == "" && r.URL.Query().Get("page") != "1" {
+			t.Errorf("first request query = %q, want page=1", r.URL.RawQuery)
+		}
+		if n, ok := next[cursor]; ok {
+			w.Header().Set("Link", fmt.Sprintf(`<?cursor=%s>; rel="next"`, n))
+		}
+		fmt.Fprint(w, pages[cursor])
+	}))
+	defer srv.Close()
+
+	c, err := New(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	posts, err := c.Posts.List(&PostFilter{PageSize: 2}).All(context.Background())
+	if err != nil {
+		t.Fatalf("All() error = %v", err)
+	}
+
+	var ids []int64
+	for _, post := range posts {
+		ids = append(ids, post.ID)
+	}
+	if want := []int64{1, 2, 3, 4, 5}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("ids = %v, want %v", ids, want)
+	}
+	for _, path := range paths {
+		if path != "/v2/posts" {
+			t.Errorf("request path = %q, want /v2/posts", path)
+		}
+	}
+}
+
+func TestIteratorLastPage(t *testing.T) {
+	tests := []struct {
+		name     string
+		pages    []string
+		requests int
+		items    int
+	}{
+		{"short page", []string{`[{"id":1}]`}, 1, 1},
+		{"empty page", []string{`[{"id":1},{"id":2}]`, `[]`}, 2, 2},
+		{"full last page", []string{`[{"id":1},{"id":2}]`, `[{"id":3},{"id":4}]`, `[]`}, 3, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if requests > len(tt.pages) {
+					t.Errorf("unexpected request for page %s", r.URL.Query().Get("page"))
+					fmt.Fprint(w, `[]`)
+					return
+				}
+				fmt.Fprint(w, tt.pages[requests-1])
+			}))
+			defer srv.Close()
+
+			c, err := New(srv.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			posts, err := c.Posts.List(&PostFilter{PageSize: 2}).All(context.Background())
+			if err != nil {
+				t.Fatalf("All() error = %v", err)
+			}
+			if len(posts) != tt.items {
+				t.Errorf("got %d posts, want %d", len(posts), tt.items)
+			}
+			if requests != tt.requests {
+				t.Errorf("got %d requests, want %d", requests, tt.requests)
+			}
+		})
+	}
+}
+
+func TestIteratorStopsAfterLastCursorPage(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Query().Get("cursor") == "" {
+			w.Header().Set("Link", `</v2/posts?cursor=abc>; rel="next"`)
+			fmt.Fprint(w, `[{"id":1},{"id":2}]`)
+			return
+		}
+		// A full page without a next link is still the last one
+		fmt.Fprint(w, `[{"id":3},{"id":4}]`)
+	}))
+	defer srv.Close()
+
+	c, err := New(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	posts, err := c.Posts.List(&PostFilter{PageSize: 2}).All(context.Background())
+	if err != nil {
+		t.Fatalf("All() error = %v", err)
+	}
+	if len(posts) != 4 || requests != 2 {
+		t.Errorf("got %d posts in %d requests, want 4 in 2", len(posts), requests)
+	}
+}
+
+func TestNextLink(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers []string
+		want    string
+	}{
+		{"none", nil, ""},
+		{"relative", []string{`<?cursor=abc>; rel="next"`}, "?cursor=abc"},
+		{"unquoted", []string{`</v2/posts?page=2>; rel=next`}, "/v2/posts?page=2"},
+		{"among others", []string{`</docs>; rel="help", <?cursor=x>; rel="next"`}, "?cursor=x"},
+		{"separate headers", []string{`</docs>; rel="help"`, `<?cursor=y>; rel="next"`}, "?cursor=y"},
+		{"no next", []string{`</v2/posts?page=1>; rel="prev"`}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nextLink(tt.headers); got != tt.want {
+				t.Errorf("nextLink() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
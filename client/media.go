@@ -0,0 +1,120 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strings"
+)
+
+// MediaService covers uploaded files
+type MediaService struct {
+	client *Client
+}
+
+// Upload streams r to the media service as filename. The body is not
+// buffered, so failed uploads are not retried.
+func (s *MediaService) Upload(ctx context.Context, r io.Reader, filename string, opts *UploadOptions) (*Media, error) {
+	if opts == nil {
+		opts = &UploadOptions{}
+	}
+
+	pr, pw := io.Pipe()
+	form := multipart.NewWriter(pw)
+
+	go func() {
+		pw.CloseWithError(writeUpload(form, r, filename, opts))
+	}()
+	// Unblocks the writer if the request ends before the body is consumed
+	defer pr.Close()
+
+	var media Media
+	req := request{
+		method:      http.MethodPost,
+		path:        "/media",
+		body:        pr,
+		contentType: form.FormDataContentType(),
+	}
+	if _, err := s.client.do(ctx, req, &media); err != nil {
+		return nil, err
+	}
+	return &media, nil
+}
+
+func writeUpload(form *multipart.Writer, r io.Reader, filename string, opts *UploadOptions) error {
+	fields := map[string]string{
+		"title":       opts.Title,
+		"description": opts.Description,
+		"alt_text":    opts.AltText,
+	}
+	for name, value := range fields {
+		if value == "" {
+			continue
+		}
+		if err := form.WriteField(name, value); err != nil {
+			return err
+		}
+	}
+
+	contentType := opts.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename="%s"`, escapeQuotes(filename)))
+	header.Set("Content-Type", contentType)
+
+	part, err := form.CreatePart(header)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(part, r); err != nil {
+		return err
+	}
+	return form.Close()
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+func escapeQuotes(s string) string {
+	return quoteEscaper.Replace(s)
+}
+
+// Get fetches the metadata of an uploaded file
+func (s *MediaService) Get(ctx context.Context, id string) (*Media, error) {
+	var media Media
+	if _, err := s.client.do(ctx, request{method: http.MethodGet, path: mediaPath(id)}, &media); err != nil {
+		return nil, err
+	}
+	return &media, nil
+}
+
+// Download writes the contents of an uploaded file to w
+func (s *MediaService) Download(ctx context.Context, id string, w io.Writer) error {
+	resp, err := s.client.do(ctx, request{method: http.MethodGet, path: mediaPath(id) + "/download"}, nil)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(resp.body)
+	return err
+}
+
+// UpdateMetadata replaces the descriptive metadata of an uploaded file
+func (s *MediaService) UpdateMetadata(ctx context.Context, id string, metadata *MediaMetadata) error {
+	_, err := s.client.do(ctx, request{method: http.MethodPut, path: mediaPath(id) + "/metadata", body: metadata}, nil)
+	return err
+}
+
+// Delete removes an uploaded file
+func (s *MediaService) Delete(ctx context.Context, id string) error {
+	_, err := s.client.do(ctx, request{method: http.MethodDelete, path: mediaPath(id)}, nil)
+	return err
+}
+
+func mediaPath(id string) string {
+	return "/media/" + url.PathEscape(id)
+}
@@ -0,0 +1,356 @@
+package client
+
+import "time"
+
+type User struct {
+	ID        int64     `json:"id"`
+	Username  string    `json:"username"`
+	Email     string    `json:"email"`
+	FullName  string    `json:"full_name"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type UserCreate struct {
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	Password string `json:"password"`
+	FullName string `json:"full_name"`
+}
+
+type Post struct {
+	ID            int64      `json:"id"`
+	Title         string     `json:"title"`
+	Content       string     `json:"content"`
+	ContentFormat string     `json:"content_format"`
+	ContentHTML   string     `json:"content_html"` // sanitized, with heading anchors
+	TOC           []TOCEntry `json:"toc"`
+	Excerpt       string     `json:"excerpt"`
+	ReadingTime   int        `json:"reading_time"` // minutes
+	Slug          string     `json:"slug"`
+	AuthorID      int64      `json:"author_id"`
+	CategoryID    int64      `json:"category_id"`
+	Status        string     `json:"status"`
+	Tags          []string   `json:"tags"`
+	ViewCount     int64      `json:"view_count"`
+	PublishedAt   time.Time  `json:"published_at,omitempty"`
+	PublishAt     *time.Time `json:"publish_at,omitempty"`
+	Meta          PostMeta   `json:"meta"`
+	SEO           *SEO       `json:"seo,omitempty"`       // set by Posts.Get
+	Highlight     string     `json:"highlight,omitempty"` // set on search results, matches in <mark>
+	Series        *SeriesNav `json:"series,omitempty"`    // set by Posts.Get for parts of a series
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// PostCreate creates a draft, a published post or, with Status "scheduled"
+// and a future PublishAt, a post that is published automatically
+type PostCreate struct {
+	Title         string     `json:"title"`
+	Content       string     `json:"content"`
+	ContentFormat string     `json:"content_format,omitempty"` // markdown (default), html or plaintext
+	CategoryID    int64      `json:"category_id"`
+	Tags          []string   `json:"tags,omitempty"`
+	Status        string     `json:"status"`
+	PublishAt     *time.Time `json:"publish_at,omitempty"`
+	PostMeta
+}
+
+type PostUpdate struct {
+	Title         string     `json:"title,omitempty"`
+	Content       string     `json:"content,omitempty"`
+	ContentFormat string     `json:"content_format,omitempty"`
+	CategoryID    int64      `json:"category_id,omitempty"`
+	Tags          []string   `json:"tags,omitempty"`
+	Status        string     `json:"status,omitempty"`
+	PublishAt     *time.Time `json:"publish_at,omitempty"`
+	PostMeta
+}
+
+// PostMeta holds the SEO fields of a post; empty fields get defaults
+type PostMeta struct {
+	MetaTitle       string `json:"meta_title,omitempty"`
+	MetaDescription string `json:"meta_description,omitempty"`
+	CanonicalURL    string `json:"canonical_url,omitempty"`
+	OGImageID       string `json:"og_image_id,omitempty"` // media ID
+}
+
+// SEO is the metadata to embed in a post's page
+type SEO struct {
+	Title        string `json:"title"`
+	Description  string `json:"description"`
+	CanonicalURL string `json:"canonical_url"`
+	Image        string `json:"image,omitempty"`
+}
+
+// TOCEntry is a heading of a post; ID is the anchor in ContentHTML
+type TOCEntry struct {
+	Level    int        `json:"level"`
+	ID       string     `json:"id"`
+	Title    string     `json:"title"`
+	Children []TOCEntry `json:"children,omitempty"`
+}
+
+// PostFilter narrows Posts.List; zero values are ignored
+type PostFilter struct {
+	Category string
+	Status   string
+	Tag      string
+	PageSize int
+
+	// Posts in any of several categories or by any of several authors
+	Categories []string
+	AuthorIDs  []int64
+
+	// Tags matches posts with any of the tags, or every one with AllTags
+	Tags    []string
+	AllTags bool
+
+	PublishedAfter  time.Time // inclusive
+	PublishedBefore time.Time // exclusive
+	ExcludeIDs      []int64
+
+	// Query searches title, tags and content in web search syntax
+	Query string
+
+	// Sort is published_at (the default), view_count, title, updated_at or
+	// relevance (the default with a Query) and Order asc or desc; the server
+	// picks the order when it is empty
+	Sort  string
+	Order string
+}
+
+// PostRevision is a snapshot of a post. Revision 1 is the post as created.
+type PostRevision struct {
+	Revision      int       `json:"revision"`
+	AuthorID      int64     `json:"author_id"`
+	Title         string    `json:"title"`
+	Content       string    `json:"content"`
+	ContentFormat string    `json:"content_format"`
+	Tags          []string  `json:"tags"`
+	CategoryID    int64     `json:"category_id"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type RevisionDiff struct {
+	From int    `json:"from"`
+	To   int    `json:"to"`
+	Diff string `json:"diff"`
+}
+
+type Category struct {
+	ID          int64        `json:"id"`
+	Name        string       `json:"name"`
+	Slug        string       `json:"slug"`
+	Description string       `json:"description"`
+	ParentID    *int64       `json:"parent_id,omitempty"`
+	Path        []Breadcrumb `json:"path,omitempty"`     // set by Get
+	Children    []*Category  `json:"children,omitempty"` // set by Tree
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+// Breadcrumb is one category on the path from the root to another
+type Breadcrumb struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+type CategoryCreate struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	ParentID    *int64 `json:"parent_id,omitempty"`
+}
+
+type CategoryUpdate struct {
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+type Tag struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	Slug        string    `json:"slug"`
+	Description string    `json:"description"`
+	PostCount   int64     `json:"post_count"`
+	Aliases     []string  `json:"aliases,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type TagUpdate struct {
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// TagCloudEntry is a tag weighted from 1 for the least used to 5 for the most
+type TagCloudEntry struct {
+	Name      string `json:"name"`
+	Slug      string `json:"slug"`
+	PostCount int64  `json:"post_count"`
+	Weight    int    `json:"weight"`
+}
+
+type Series struct {
+	ID          int64        `json:"id"`
+	Title       string       `json:"title"`
+	Slug        string       `json:"slug"`
+	Description string       `json:"description"`
+	AuthorID    int64        `json:"author_id"`
+	Parts       int          `json:"parts"`
+	Posts       []SeriesPart `json:"posts,omitempty"` // published parts, set by Get
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+type SeriesCreate struct {
+	Title       string  `json:"title"`
+	Description string  `json:"description,omitempty"`
+	PostIDs     []int64 `json:"post_ids,omitempty"`
+}
+
+type SeriesUpdate struct {
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// SeriesPart is a post of a series; parts are numbered from 1
+type SeriesPart struct {
+	ID    int64  `json:"id"`
+	Title string `json:"title"`
+	Slug  string `json:"slug"`
+	Part  int    `json:"part"`
+}
+
+// SeriesNav places a post within its series, with the nearest published
+// parts before and after it
+type SeriesNav struct {
+	ID       int64       `json:"id"`
+	Title    string      `json:"title"`
+	Slug     string      `json:"slug"`
+	Part     int         `json:"part"`
+	Parts    int         `json:"parts"`
+	Previous *SeriesPart `json:"previous,omitempty"`
+	Next     *SeriesPart `json:"next,omitempty"`
+}
+
+type Comment struct {
+	ID        string          `json:"id"`
+	PostID    string          `json:"post_id"`
+	UserID    int64           `json:"user_id"`
+	ParentID  *string         `json:"parent_id,omitempty"`
+	Content   string          `json:"content"`
+	Status    string          `json:"status"`
+	Metadata  CommentMetadata `json:"metadata"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+	Children  []*Comment      `json:"children,omitempty"`
+}
+
+type CommentMetadata struct {
+	Likes    int64      `json:"likes"`
+	Reports  int64      `json:"reports"`
+	EditedAt *time.Time `json:"edited_at,omitempty"`
+	EditorID *int64     `json:"editor_id,omitempty"`
+}
+
+type CommentCreate struct {
+	PostID   string `json:"post_id"`
+	ParentID string `json:"parent_id,omitempty"`
+	Content  string `json:"content"`
+}
+
+// CommentFilter narrows Comments.List; zero values are ignored
+type CommentFilter struct {
+	PostID   string
+	UserID   int64
+	ParentID string
+	Status   string
+	PageSize int
+}
+
+type Media struct {
+	ID          string        `json:"id"`
+	UserID      int64         `json:"user_id"`
+	Filename    string        `json:"filename"`
+	ContentType string        `json:"content_type"`
+	Size        int64         `json:"size"`
+	URL         string        `json:"url"`
+	Metadata    MediaMetadata `json:"metadata"`
+	CreatedAt   time.Time     `json:"created_at"`
+}
+
+type MediaMetadata struct {
+	Width       int    `json:"width,omitempty"`
+	Height      int    `json:"height,omitempty"`
+	Format      string `json:"format,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	AltText     string `json:"alt_text,omitempty"`
+}
+
+type SearchRequest struct {
+	Query     string   `json:"query"`
+	Type      string   `json:"type"`
+	From      int      `json:"from"`
+	Size      int      `json:"size,omitempty"`
+	Status    string   `json:"status,omitempty"`
+	Tags      []string `json:"tags,omitempty"`
+	Category  string   `json:"category,omitempty"`
+	SortBy    string   `json:"sort_by,omitempty"`
+	SortOrder string   `json:"sort_order,omitempty"`
+}
+
+type SearchResponse struct {
+	Total    int64           `json:"total"`
+	From     int             `json:"from"`
+	Size     int             `json:"size"`
+	Posts    []SearchPost    `json:"posts,omitempty"`
+	Comments []SearchComment `json:"comments,omitempty"`
+}
+
+type SearchPost struct {
+	ID          string    `json:"id"`
+	Title       string    `json:"title"`
+	Content     string    `json:"content"`
+	Excerpt     string    `json:"excerpt"`
+	AuthorID    int64     `json:"author_id"`
+	AuthorName  string    `json:"author_name"`
+	Categories  []string  `json:"categories"`
+	Tags        []string  `json:"tags"`
+	Status      string    `json:"status"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	PublishedAt time.Time `json:"published_at,omitempty"`
+}
+
+type SearchComment struct {
+	ID        string    `json:"id"`
+	PostID    string    `json:"post_id"`
+	Content   string    `json:"content"`
+	UserID    int64     `json:"user_id"`
+	UserName  string    `json:"user_name"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type SuggestionRequest struct {
+	Query  string `json:"query"`
+	Type   string `json:"type"`
+	Limit  int    `json:"limit,omitempty"`
+	Status string `json:"status,omitempty"`
+}
+
+type SuggestionResponse struct {
+	Suggestions []string `json:"suggestions"`
+}
+
+// UploadOptions describes a file passed to Media.Upload
+type UploadOptions struct {
+	ContentType string
+	Title       string
+	Description string
+	AltText     string
+}
@@ -0,0 +1,158 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// PostsService covers blog posts
+type PostsService struct {
+	client *Client
+}
+
+// Create publishes or drafts a new post depending on input.Status
+func (s *PostsService) Create(ctx context.Context, input *PostCreate) (*Post, error) {
+	var post Post
+	if _, err := s.client.do(ctx, request{method: http.MethodPost, path: "/posts", body: input}, &post); err != nil {
+		return nil, err
+	}
+	return &post, nil
+}
+
+// Get fetches a single post by slug
+func (s *PostsService) Get(ctx context.Context, slug string) (*Post, error) {
+	var post Post
+	if _, err := s.client.do(ctx, request{method: http.MethodGet, path: postPath(slug)}, &post); err != nil {
+		return nil, err
+	}
+	return &post, nil
+}
+
+// Update changes the non-zero fields of input. Changing the title also
+// changes the slug of the post.
+func (s *PostsService) Update(ctx context.Context, slug string, input *PostUpdate) (*Post, error) {
+	var post Post
+	if _, err := s.client.do(ctx, request{method: http.MethodPut, path: postPath(slug), body: input}, &post); err != nil {
+		return nil, err
+	}
+	return &post, nil
+}
+
+// Delete removes a post
+func (s *PostsService) Delete(ctx context.Context, slug string) error {
+	_, err := s.client.do(ctx, request{method: http.MethodDelete, path: postPath(slug)}, nil)
+	return err
+}
+
+// List returns an iterator over the posts matching filter, which may be nil
+func (s *PostsService) List(filter *PostFilter) *Iterator[Post] {
+	query := url.Values{}
+	pageSize := 0
+	if filter != nil {
+		setQuery(query, "category", filter.Category)
+		setQuery(query, "status", filter.Status)
+		setQuery(query, "tag", filter.Tag)
+		pageSize = filter.PageSize
+
+		for _, category := range filter.Categories {
+			query.Add("category", category)
+		}
+		for _, id := range filter.AuthorIDs {
+			query.Add("author", strconv.FormatInt(id, 10))
+		}
+		for _, tag := range filter.Tags {
+			query.Add("tag", tag)
+		}
+		if filter.AllTags {
+			query.Set("tag_match", "all")
+		}
+		if !filter.PublishedAfter.IsZero() {
+			query.Set("published_after", filter.PublishedAfter.Format(time.RFC3339))
+		}
+		if !filter.PublishedBefore.IsZero() {
+			query.Set("published_before", filter.PublishedBefore.Format(time.RFC3339))
+		}
+		for _, id := range filter.ExcludeIDs {
+			query.Add("exclude", strconv.FormatInt(id, 10))
+		}
+		setQuery(query, "q", filter.Query)
+		setQuery(query, "sort", filter.Sort)
+		setQuery(query, "order", filter.Order)
+	}
+	return newIterator[Post](s.client, "/posts", query, pageSize)
+}
+
+// Scheduled returns up to limit scheduled posts, soonest first; zero
+// requests the server maximum
+func (s *PostsService) Scheduled(ctx context.Context, limit int) ([]Post, error) {
+	query := url.Values{}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	var posts []Post
+	if _, err := s.client.do(ctx, request{method: http.MethodGet, path: "/posts/scheduled", query: query}, &posts); err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+// Revisions returns the history of a post, newest first
+func (s *PostsService) Revisions(ctx context.Context, slug string) ([]PostRevision, error) {
+	var revisions []PostRevision
+	if _, err := s.client.do(ctx, request{method: http.MethodGet, path: postPath(slug) + "/revisions"}, &revisions); err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+// Revision fetches a single revision of a post
+func (s *PostsService) Revision(ctx context.Context, slug string, revision int) (*PostRevision, error) {
+	var rev PostRevision
+	if _, err := s.client.do(ctx, request{method: http.MethodGet, path: revisionPath(slug, revision)}, &rev); err != nil {
+		return nil, err
+	}
+	return &rev, nil
+}
+
+// Diff returns a unified diff between two revisions; a zero to compares
+// against the latest revision
+func (s *PostsService) Diff(ctx context.Context, slug string, from, to int) (*RevisionDiff, error) {
+	query := url.Values{"from": {strconv.Itoa(from)}}
+	if to != 0 {
+		query.Set("to", strconv.Itoa(to))
+	}
+
+	var diff RevisionDiff
+	if _, err := s.client.do(ctx, request{method: http.MethodGet, path: postPath(slug) + "/revisions/diff", query: query}, &diff); err != nil {
+		return nil, err
+	}
+	return &diff, nil
+}
+
+// Restore copies an older revision back onto the post as a new revision
+func (s *PostsService) Restore(ctx context.Context, slug string, revision int) (*Post, error) {
+	var post Post
+	if _, err := s.client.do(ctx, request{method: http.MethodPost, path: revisionPath(slug, revision) + "/restore"}, &post); err != nil {
+		return nil, err
+	}
+	return &post, nil
+}
+
+func revisionPath(slug string, revision int) string {
+	return postPath(slug) + "/revisions/" + strconv.Itoa(revision)
+}
+
+func postPath(slug string) string {
+	return "/posts/" + url.PathEscape(slug)
+}
+
+// setQuery adds key to query unless value is empty
+func setQuery(query url.Values, key, value string) {
+	if value != "" {
+		query.Set(key, value)
+	}
+}
@@ -0,0 +1,76 @@
+package client
+
+import (
+	"errors"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how transient failures are retried. Only idempotent
+// requests are retried, so a create is never sent twice.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt
+	MaxRetries int
+
+	// BaseDelay is doubled after each attempt, up to MaxDelay, with jitter
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+}
+
+// DefaultRetryPolicy retries up to three times starting at 200ms
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries: 3,
+	BaseDelay:  200 * time.Millisecond,
+	MaxDelay:   5 * time.Second,
+}
+
+// NoRetry disables retries
+var NoRetry = RetryPolicy{}
+
+func (p RetryPolicy) shouldRetry(req request, attempt int, resp *response, err error) bool {
+	if attempt >= p.MaxRetries || !(req.idempotent || idempotent(req.method)) {
+		return false
+	}
+
+	if err != nil {
+		var netErr net.Error
+		return errors.As(err, &netErr)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// delay returns how long to wait before the next attempt, honouring
+// Retry-After when the server sent one
+func (p RetryPolicy) delay(attempt int, resp *response) time.Duration {
+	if resp != nil {
+		if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && secs >= 0 {
+			return time.Duration(secs) * time.Second
+		}
+	}
+
+	d := p.BaseDelay << attempt
+	if p.MaxDelay > 0 && (d <= 0 || d > p.MaxDelay) {
+		d = p.MaxDelay
+	}
+	if d <= 0 {
+		return 0
+	}
+	// Full jitter spreads out retries from many clients
+	return time.Duration(rand.Int63n(int64(d) + 1))
+}
+
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	}
+	return false
+}
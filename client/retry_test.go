@@ -0,0 +1,50 @@
+package client
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyDelay(t *testing.T) {
+	policy := RetryPolicy{MaxRetries: 3, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	tests := []struct {
+		name       string
+		attempt    int
+		retryAfter string
+		min, max   time.Duration
+	}{
+		{"retry after seconds", 0, "3", 3 * time.Second, 3 * time.Second},
+		{"retry after zero", 2, "0", 0, 0},
+		{"retry after beyond max delay", 0, "10", 10 * time.Second, 10 * time.Second},
+		{"retry after date falls back", 1, "Wed, 21 Oct 2015 07:28:00 GMT", 0, 200 * time.Millisecond},
+		{"negative retry after falls back", 0, "-1", 0, 100 * time.Millisecond},
+		{"backoff", 2, "", 0, 400 * time.Millisecond},
+		{"backoff capped", 8, "", 0, time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &response{Response: &http.Response{Header: http.Header{}}}
+			if tt.retryAfter != "" {
+				resp.Header.Set("Retry-After", tt.retryAfter)
+			}
+
+			for i := 0; i < 20; i++ {
+				if d := policy.delay(tt.attempt, resp); d < tt.min || d > tt.max {
+					t.Fatalf("delay() = %v, want between %v and %v", d, tt.min, tt.max)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryPolicyDelayWithoutResponse(t *testing.T) {
+	if d := NoRetry.delay(0, nil); d != 0 {
+		t.Errorf("NoRetry.delay() = %v, want 0", d)
+	}
+	if d := DefaultRetryPolicy.delay(0, nil); d > DefaultRetryPolicy.BaseDelay {
+		t.Errorf("DefaultRetryPolicy.delay() = %v, want at most %v", d, DefaultRetryPolicy.BaseDelay)
+	}
+}
@@ -0,0 +1,29 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// SearchService covers full-text search and suggestions
+type SearchService struct {
+	client *Client
+}
+
+// Query runs a search. Searches are read-only, so they are retried like GETs.
+func (s *SearchService) Query(ctx context.Context, input *SearchRequest) (*SearchResponse, error) {
+	var out SearchResponse
+	if _, err := s.client.do(ctx, request{method: http.MethodPost, path: "/search", body: input, idempotent: true}, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Suggest returns completions for a partial query
+func (s *SearchService) Suggest(ctx context.Context, input *SuggestionRequest) ([]string, error) {
+	var out SuggestionResponse
+	if _, err := s.client.do(ctx, request{method: http.MethodPost, path: "/search/suggest", body: input, idempotent: true}, &out); err != nil {
+		return nil, err
+	}
+	return out.Suggestions, nil
+}
@@ -0,0 +1,94 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// SeriesService covers series, ordered multi-part collections of posts
+// addressed by slug
+type SeriesService struct {
+	client *Client
+}
+
+// List returns every series, newest first
+func (s *SeriesService) List(ctx context.Context) ([]Series, error) {
+	var list []Series
+	if _, err := s.client.do(ctx, request{method: http.MethodGet, path: "/series"}, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// Create adds a series, with input.PostIDs as its first parts
+func (s *SeriesService) Create(ctx context.Context, input *SeriesCreate) (*Series, error) {
+	var series Series
+	if _, err := s.client.do(ctx, request{method: http.MethodPost, path: "/series", body: input}, &series); err != nil {
+		return nil, err
+	}
+	return &series, nil
+}
+
+// Get fetches a series with its published parts in order
+func (s *SeriesService) Get(ctx context.Context, slug string) (*Series, error) {
+	var series Series
+	if _, err := s.client.do(ctx, request{method: http.MethodGet, path: seriesPath(slug)}, &series); err != nil {
+		return nil, err
+	}
+	return &series, nil
+}
+
+// Update changes the non-zero fields of input
+func (s *SeriesService) Update(ctx context.Context, slug string, input *SeriesUpdate) (*Series, error) {
+	var series Series
+	if _, err := s.client.do(ctx, request{method: http.MethodPut, path: seriesPath(slug), body: input}, &series); err != nil {
+		return nil, err
+	}
+	return &series, nil
+}
+
+// Delete removes a series; its posts are kept
+func (s *SeriesService) Delete(ctx context.Context, slug string) error {
+	_, err := s.client.do(ctx, request{method: http.MethodDelete, path: seriesPath(slug)}, nil)
+	return err
+}
+
+// AddPost makes a post part number part of the series, or the last part when
+// part is zero
+func (s *SeriesService) AddPost(ctx context.Context, slug string, postID int64, part int) (*Series, error) {
+	input := map[string]int64{"post_id": postID}
+	if part > 0 {
+		input["part"] = int64(part)
+	}
+
+	var series Series
+	if _, err := s.client.do(ctx, request{method: http.MethodPost, path: seriesPath(slug) + "/posts", body: input}, &series); err != nil {
+		return nil, err
+	}
+	return &series, nil
+}
+
+// RemovePost takes a post out of the series
+func (s *SeriesService) RemovePost(ctx context.Context, slug string, postID int64) error {
+	path := seriesPath(slug) + "/posts/" + strconv.FormatInt(postID, 10)
+	_, err := s.client.do(ctx, request{method: http.MethodDelete, path: path}, nil)
+	return err
+}
+
+// Reorder puts the parts of the series in the order of postIDs, which must
+// list every one of them
+func (s *SeriesService) Reorder(ctx context.Context, slug string, postIDs []int64) (*Series, error) {
+	input := map[string][]int64{"post_ids": postIDs}
+
+	var series Series
+	if _, err := s.client.do(ctx, request{method: http.MethodPut, path: seriesPath(slug) + "/posts", body: input}, &series); err != nil {
+		return nil, err
+	}
+	return &series, nil
+}
+
+func seriesPath(slug string) string {
+	return "/series/" + url.PathEscape(slug)
+}
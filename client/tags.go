@@ -0,0 +1,88 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// TagsService covers post tags, which are addressed by slug. Aliases and
+// other spellings of a tag resolve to it.
+type TagsService struct {
+	client *Client
+}
+
+// List returns every tag by name
+func (s *TagsService) List(ctx context.Context) ([]Tag, error) {
+	var tags []Tag
+	if _, err := s.client.do(ctx, request{method: http.MethodGet, path: "/tags"}, &tags); err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// Cloud returns up to limit of the most used tags, by name; zero uses the
+// server's default
+func (s *TagsService) Cloud(ctx context.Context, limit int) ([]TagCloudEntry, error) {
+	query := url.Values{}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	var entries []TagCloudEntry
+	if _, err := s.client.do(ctx, request{method: http.MethodGet, path: "/tag-cloud", query: query}, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// Get fetches a tag with its aliases
+func (s *TagsService) Get(ctx context.Context, slug string) (*Tag, error) {
+	var tag Tag
+	if _, err := s.client.do(ctx, request{method: http.MethodGet, path: tagPath(slug)}, &tag); err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// Update changes the non-zero fields of input
+func (s *TagsService) Update(ctx context.Context, slug string, input *TagUpdate) (*Tag, error) {
+	var tag Tag
+	if _, err := s.client.do(ctx, request{method: http.MethodPut, path: tagPath(slug), body: input}, &tag); err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// AddAlias makes alias resolve to the tag
+func (s *TagsService) AddAlias(ctx context.Context, slug, alias string) (*Tag, error) {
+	input := map[string]string{"alias": alias}
+
+	var tag Tag
+	if _, err := s.client.do(ctx, request{method: http.MethodPost, path: tagPath(slug) + "/aliases", body: input}, &tag); err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// RemoveAlias removes an alias of the tag
+func (s *TagsService) RemoveAlias(ctx context.Context, slug, alias string) error {
+	_, err := s.client.do(ctx, request{method: http.MethodDelete, path: tagPath(slug) + "/aliases/" + url.PathEscape(alias)}, nil)
+	return err
+}
+
+// Merge moves the tag's posts and aliases to into and returns the merged tag
+func (s *TagsService) Merge(ctx context.Context, slug, into string) (*Tag, error) {
+	input := map[string]string{"into": into}
+
+	var tag Tag
+	if _, err := s.client.do(ctx, request{method: http.MethodPost, path: tagPath(slug) + "/merge", body: input}, &tag); err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+func tagPath(slug string) string {
+	return "/tags/" + url.PathEscape(slug)
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/lib/pq"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// minPasswordLength matches the auth service's registration rule
+const minPasswordLength = 6
+
+// runAdmin writes to the auth database directly, since no admin exists yet
+// that could authorize an API call
+func runAdmin(ctx context.Context, app *app, args []string) error {
+	name, args := subcommand(args)
+	switch name {
+	case "create":
+		return adminCreate(ctx, app, args)
+	case "promote":
+		return adminPromote(ctx, app, args)
+	}
+	return errUsage
+}
+
+func adminCreate(ctx context.Context, app *app, args []string) error {
+	fs := newFlags("admin create")
+	email := fs.String("email", "", "email address (required)")
+	username := fs.String("username", "admin", "username")
+	fullName := fs.String("name", "Administrator", "full name")
+	password := fs.String("password", "", "password; read from BLOGBISH_ADMIN_PASSWORD or stdin when empty")
+	if err := fs.Parse(args); err != nil || *email == "" {
+		return errUsage
+	}
+
+	if *password == "" {
+		*password = os.Getenv("BLOGBISH_ADMIN_PASSWORD")
+	}
+	if *password == "" {
+		var err error
+		if *password, err = readPassword(); err != nil {
+			return err
+		}
+	}
+	if len(*password) < minPasswordLength {
+		return fmt.Errorf("password must be at least %d characters", minPasswordLength)
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(*password), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	db, err := openDB(ctx, app.cfg.Database)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	var id int64
+	err = db.QueryRowContext(ctx, `
+		INSERT INTO users (username, email, password, full_name, role, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, 'admin', NOW(), NOW())
+		RETURNING id`,
+		*username, *email, string(hash), *fullName,
+	).Scan(&id)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return fmt.Errorf("a user with that email or username already exists; use \"admin promote\" instead")
+		}
+		return fmt.Errorf("failed to create admin: %w", err)
+	}
+
+	fmt.Printf("created admin %s (id %d)\n", *email, id)
+	return nil
+}
+
+func adminPromote(ctx context.Context, app *app, args []string) error {
+	fs := newFlags("admin promote")
+	email := fs.String("email", "", "email address of the user (required)")
+	if err := fs.Parse(args); err != nil || *email == "" {
+		return errUsage
+	}
+
+	db, err := openDB(ctx, app.cfg.Database)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	result, err := db.ExecContext(ctx,
+		`UPDATE users SET role = 'admin', updated_at = NOW() WHERE email = $1`, *email)
+	if err != nil {
+		return fmt.Errorf("failed to promote user: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("no user with email %s", *email)
+	}
+
+	fmt.Printf("promoted %s to admin\n", *email)
+	return nil
+}
+
+func openDB(ctx context.Context, cfg DatabaseConfig) (*sql.DB, error) {
+	db, err := sql.Open("postgres", cfg.DSN())
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	return db, nil
+}
+
+// readPassword reads a single line from stdin so passwords can be piped in
+// without showing up in the process list
+func readPassword() (string, error) {
+	fmt.Fprint(os.Stderr, "Password: ")
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("failed to read password: %w", err)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Thedrogon/blogbish/client"
+)
+
+// app holds the config and the clients built from it on first use
+type app struct {
+	cfg  *Config
+	http *http.Client
+	api  *client.Client
+}
+
+func newApp(cfg *Config) *app {
+	return &app{
+		cfg:  cfg,
+		http: &http.Client{Timeout: cfg.Timeout.Duration},
+	}
+}
+
+// client returns a gateway API client authenticated from the config
+func (a *app) client() (*client.Client, error) {
+	if a.api != nil {
+		return a.api, nil
+	}
+
+	opts := []client.Option{
+		client.WithHTTPClient(a.http),
+		client.WithUserAgent("blogbish-cli"),
+	}
+	switch {
+	case a.cfg.Token != "":
+		opts = append(opts, client.WithToken(a.cfg.Token))
+	case a.cfg.Email != "":
+		opts = append(opts, client.WithCredentials(a.cfg.Email, a.cfg.Password))
+	}
+
+	c, err := client.New(a.cfg.Gateway, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gateway URL: %w", err)
+	}
+	a.api = c
+	return c, nil
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/Thedrogon/blogbish/Internals/cachekeys"
+	"github.com/redis/go-redis/v9"
+)
+
+// cachePatterns lists the Redis keys each service caches under
+var cachePatterns = map[string][]string{
+	"post": {
+		cachekeys.Pattern(cachekeys.Post),
+		cachekeys.Pattern(cachekeys.Category),
+		cachekeys.Pattern(cachekeys.Feed),
+		cachekeys.Pattern(cachekeys.Sitemap),
+	},
+	"media": {cachekeys.Pattern(cachekeys.Media)},
+	"search": {
+		cachekeys.Pattern(cachekeys.Search),
+		cachekeys.Pattern(cachekeys.Suggest),
+	},
+}
+
+// scanBatch is the number of keys requested per SCAN call
+const scanBatch = 500
+
+func runCache(ctx context.Context, app *app, args []string) error {
+	name, args := subcommand(args)
+	if name != "purge" {
+		return errUsage
+	}
+
+	fs := newFlags("cache purge")
+	service := fs.String("service", "all", "service whose cache to purge: "+strings.Join(cacheServices(), ", ")+" or all")
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+
+	var patterns []string
+	if *service == "all" {
+		for _, name := range cacheServices() {
+			patterns = append(patterns, cachePatterns[name]...)
+		}
+	} else if patterns = cachePatterns[*service]; patterns == nil {
+		return fmt.Errorf("unknown service %q", *service)
+	}
+
+	rdb := redis.NewClient(&redis.Options{
+		Addr:     app.cfg.Redis.Host + ":" + app.cfg.Redis.Port,
+		Password: app.cfg.Redis.Password,
+		DB:       app.cfg.Redis.DB,
+	})
+	defer rdb.Close()
+
+	for _, pattern := range patterns {
+		n, err := purge(ctx, rdb, pattern)
+		if err != nil {
+			return fmt.Errorf("failed to purge %s: %w", pattern, err)
+		}
+		fmt.Fprintf(os.Stderr, "removed %d keys matching %s\n", n, pattern)
+	}
+	return nil
+}
+
+// purge deletes keys matching pattern with SCAN so Redis is never blocked
+// the way KEYS would block it
+func purge(ctx context.Context, rdb *redis.Client, pattern string) (int, error) {
+	removed := 0
+	iter := rdb.Scan(ctx, 0, pattern, scanBatch).Iterator()
+
+	batch := make([]string, 0, scanBatch)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		n, err := rdb.Unlink(ctx, batch...).Result()
+		removed += int(n)
+		batch = batch[:0]
+		return err
+	}
+
+	for iter.Next(ctx) {
+		batch = append(batch, iter.Val())
+		if len(batch) == scanBatch {
+			if err := flush(); err != nil {
+				return removed, err
+			}
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return removed, err
+	}
+	return removed, flush()
+}
+
+func cacheServices() []string {
+	names := make([]string, 0, len(cachePatterns))
+	for name := range cachePatterns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
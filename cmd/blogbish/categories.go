@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/Thedrogon/blogbish/client"
+)
+
+func runCategories(ctx context.Context, app *app, args []string) error {
+	api, err := app.client()
+	if err != nil {
+		return err
+	}
+
+	name, args := subcommand(args)
+	switch name {
+	case "list":
+		categories, err := api.Categories.List(ctx)
+		if err != nil {
+			return err
+		}
+		tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "ID\tSLUG\tNAME\tDESCRIPTION")
+		for _, c := range categories {
+			fmt.Fprintf(tw, "%d\t%s\t%s\t%s\n", c.ID, c.Slug, c.Name, c.Description)
+		}
+		return tw.Flush()
+
+	case "create":
+		fs := newFlags("categories create")
+		catName := fs.String("name", "", "category name (required)")
+		description := fs.String("description", "", "category description (required)")
+		parent := fs.String("parent", "", "slug of the parent category")
+		if err := fs.Parse(args); err != nil || *catName == "" || *description == "" {
+			return errUsage
+		}
+		parentID, err := categoryID(ctx, api, *parent)
+		if err != nil {
+			return err
+		}
+		category, err := api.Categories.Create(ctx, &client.CategoryCreate{Name: *catName, Description: *description, ParentID: parentID})
+		if err != nil {
+			return err
+		}
+		fmt.Printf("created category %s (id %d)\n", category.Slug, category.ID)
+		return nil
+
+	case "update":
+		fs := newFlags("categories update")
+		slug := fs.String("slug", "", "slug of the category (required)")
+		catName := fs.String("name", "", "new name")
+		description := fs.String("description", "", "new description")
+		if err := fs.Parse(args); err != nil || *slug == "" {
+			return errUsage
+		}
+		category, err := api.Categories.Update(ctx, *slug, &client.CategoryUpdate{Name: *catName, Description: *description})
+		if err != nil {
+			return err
+		}
+		fmt.Printf("updated category %s\n", category.Slug)
+		return nil
+
+	case "move":
+		fs := newFlags("categories move")
+		slug := fs.String("slug", "", "slug of the category (required)")
+		parent := fs.String("parent", "", "slug of the new parent; the root when empty")
+		if err := fs.Parse(args); err != nil || *slug == "" {
+			return errUsage
+		}
+		parentID, err := categoryID(ctx, api, *parent)
+		if err != nil {
+			return err
+		}
+		category, err := api.Categories.Move(ctx, *slug, parentID)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("moved category %s\n", category.Slug)
+		return nil
+
+	case "delete":
+		fs := newFlags("categories delete")
+		slug := fs.String("slug", "", "slug of the category (required)")
+		if err := fs.Parse(args); err != nil || *slug == "" {
+			return errUsage
+		}
+		if err := api.Categories.Delete(ctx, *slug); err != nil {
+			return err
+		}
+		fmt.Printf("deleted category %s\n", *slug)
+		return nil
+	}
+	return errUsage
+}
+
+// categoryID looks up the ID of the category with slug; nil when slug is empty
+func categoryID(ctx context.Context, api *client.Client, slug string) (*int64, error) {
+	if slug == "" {
+		return nil, nil
+	}
+	category, err := api.Categories.Get(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	return &category.ID, nil
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Thedrogon/blogbish/client"
+)
+
+// previewLength is how much of a comment "comments list" prints
+const previewLength = 60
+
+func runComments(ctx context.Context, app *app, args []string) error {
+	api, err := app.client()
+	if err != nil {
+		return err
+	}
+
+	name, args := subcommand(args)
+	switch name {
+	case "list":
+		fs := newFlags("comments list")
+		status := fs.String("status", "flagged", "only list comments with this status, empty for all")
+		postID := fs.String("post", "", "only list comments on this post")
+		if err := fs.Parse(args); err != nil {
+			return errUsage
+		}
+
+		tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "ID\tPOST\tUSER\tSTATUS\tREPORTS\tCONTENT")
+		it := api.Comments.List(&client.CommentFilter{Status: *status, PostID: *postID})
+		for it.Next(ctx) {
+			c := it.Item()
+			fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%d\t%s\n", c.ID, c.PostID, c.UserID, c.Status, c.Metadata.Reports, preview(c.Content))
+		}
+		if err := it.Err(); err != nil {
+			return err
+		}
+		return tw.Flush()
+
+	case "moderate":
+		fs := newFlags("comments moderate")
+		status := fs.String("status", "", "new status: active, hidden or deleted (required)")
+		if err := fs.Parse(args); err != nil || *status == "" || fs.NArg() == 0 {
+			fmt.Fprintln(os.Stderr, "pass the comment IDs after the flags")
+			return errUsage
+		}
+
+		for _, id := range fs.Args() {
+			if err := api.Comments.Moderate(ctx, id, *status); err != nil {
+				return fmt.Errorf("comment %s: %w", id, err)
+			}
+			fmt.Printf("comment %s is now %s\n", id, *status)
+		}
+		return nil
+	}
+	return errUsage
+}
+
+func preview(content string) string {
+	content = strings.Join(strings.Fields(content), " ")
+	runes := []rune(content)
+	if len(runes) <= previewLength {
+		return content
+	}
+	return string(runes[:previewLength-3]) + "..."
+}
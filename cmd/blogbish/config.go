@@ -0,0 +1,143 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Config is read from a JSON file. Every field has a default that matches
+// docker-compose.yml, so a missing file is only an error when it was asked
+// for explicitly.
+type Config struct {
+	// Gateway is the base URL of the API gateway
+	Gateway string `json:"gateway"`
+
+	// Token or Email and Password authenticate API calls
+	Token    string `json:"token"`
+	Email    string `json:"email"`
+	Password string `json:"password"`
+
+	Services ServicesConfig `json:"services"`
+	Database DatabaseConfig `json:"database"`
+	Redis    RedisConfig    `json:"redis"`
+
+	// Timeout bounds each HTTP request, e.g. "30s"
+	Timeout Duration `json:"timeout"`
+}
+
+// ServicesConfig holds the internal URLs of services that the CLI talks to
+// directly, bypassing the gateway
+type ServicesConfig struct {
+	Auth    string `json:"auth"`
+	Post    string `json:"post"`
+	Comment string `json:"comment"`
+	Media   string `json:"media"`
+	Search  string `json:"search"`
+}
+
+type DatabaseConfig struct {
+	Host     string `json:"host"`
+	Port     string `json:"port"`
+	User     string `json:"user"`
+	Password string `json:"password"`
+	DBName   string `json:"dbname"`
+	SSLMode  string `json:"sslmode"`
+}
+
+// DSN returns the lib/pq connection string
+func (c DatabaseConfig) DSN() string {
+	return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
+		c.Host, c.Port, c.User, c.Password, c.DBName, c.SSLMode)
+}
+
+type RedisConfig struct {
+	Host     string `json:"host"`
+	Port     string `json:"port"`
+	Password string `json:"password"`
+	DB       int    `json:"db"`
+}
+
+// Duration unmarshals from a Go duration string
+type Duration struct {
+	time.Duration
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	d.Duration = parsed
+	return nil
+}
+
+func defaultConfig() *Config {
+	return &Config{
+		Gateway: "http://localhost:8000",
+		Services: ServicesConfig{
+			Auth:    "http://localhost:8080",
+			Post:    "http://localhost:8081",
+			Media:   "http://localhost:8082",
+			Comment: "http://localhost:8083",
+			Search:  "http://localhost:8084",
+		},
+		Database: DatabaseConfig{
+			Host:     "localhost",
+			Port:     "5432",
+			User:     "postgres",
+			Password: "postgres",
+			DBName:   "blogbish",
+			SSLMode:  "disable",
+		},
+		Redis: RedisConfig{
+			Host: "localhost",
+			Port: "6379",
+		},
+		Timeout: Duration{30 * time.Second},
+	}
+}
+
+func defaultConfigPath() string {
+	if path := os.Getenv("BLOGBISH_CONFIG"); path != "" {
+		return path
+	}
+	return "blogbish.json"
+}
+
+// LoadConfig reads path over the defaults; a missing file is ignored unless
+// required. BLOGBISH_TOKEN, BLOGBISH_EMAIL and BLOGBISH_PASSWORD override the
+// credentials so they can stay out of the file.
+func LoadConfig(path string, required bool) (*Config, error) {
+	cfg := defaultConfig()
+
+	file, err := os.Open(path)
+	switch {
+	case err == nil:
+		defer file.Close()
+		if err := json.NewDecoder(file).Decode(cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse config %s: %w", path, err)
+		}
+	case os.IsNotExist(err) && !required:
+		// Defaults only
+	default:
+		return nil, fmt.Errorf("failed to open config: %w", err)
+	}
+
+	if token := os.Getenv("BLOGBISH_TOKEN"); token != "" {
+		cfg.Token = token
+	}
+	if email := os.Getenv("BLOGBISH_EMAIL"); email != "" {
+		cfg.Email = email
+	}
+	if password := os.Getenv("BLOGBISH_PASSWORD"); password != "" {
+		cfg.Password = password
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Thedrogon/blogbish/Internals/health"
+)
+
+// errUnhealthy makes the command exit non-zero so it can be used in scripts
+var errUnhealthy = errors.New("one or more services are not ready")
+
+func runHealth(ctx context.Context, app *app, args []string) error {
+	if len(args) > 0 {
+		return errUsage
+	}
+
+	targets := []struct{ name, url string }{
+		{"gateway", app.cfg.Gateway},
+		{"auth", app.cfg.Services.Auth},
+		{"post", app.cfg.Services.Post},
+		{"comment", app.cfg.Services.Comment},
+		{"media", app.cfg.Services.Media},
+		{"search", app.cfg.Services.Search},
+	}
+
+	healthy := true
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "SERVICE\tSTATUS\tDETAILS")
+	for _, target := range targets {
+		report, err := app.readiness(ctx, target.url)
+		if err != nil {
+			healthy = false
+			fmt.Fprintf(tw, "%s\t%s\t%v\n", target.name, "unreachable", err)
+			continue
+		}
+		if report.Status != health.StatusUp {
+			healthy = false
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", target.name, report.Status, checkSummary(report.Checks))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if !healthy {
+		return errUnhealthy
+	}
+	return nil
+}
+
+// readiness fetches the /readyz report of the service at baseURL. Unready
+// services answer 503 with a report, so the status code is not an error.
+func (a *app) readiness(ctx context.Context, baseURL string) (*health.Report, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(baseURL, "/")+"/readyz", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := a.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var report health.Report
+	if err := json.NewDecoder(resp.Body).Decode(&report); err != nil {
+		return nil, fmt.Errorf("unexpected %s response", resp.Status)
+	}
+	return &report, nil
+}
+
+func checkSummary(checks map[string]health.CheckResult) string {
+	names := make([]string, 0, len(checks))
+	for name := range checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		check := checks[name]
+		part := fmt.Sprintf("%s=%s (%dms)", name, check.Status, check.LatencyMS)
+		if check.Error != "" {
+			part += ": " + check.Error
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, ", ")
+}
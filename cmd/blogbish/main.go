@@ -0,0 +1,116 @@
+// Command blogbish operates a Blogbish installation: it bootstraps the first
+// admin, manages categories, imports and exports posts, rebuilds the search
+// index, purges caches, moderates comments and checks service health.
+//
+// Usage:
+//
+//	blogbish [-config blogbish.json] <command> [arguments]
+//
+// Run "blogbish help" for the list of commands.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+)
+
+// command is a top-level subcommand. Commands with subcommands dispatch on
+// their first argument themselves.
+type command struct {
+	usage string
+	help  string
+	run   func(ctx context.Context, app *app, args []string) error
+}
+
+var commands = map[string]command{
+	"admin":      {"admin create|promote [flags]", "Create the first admin user or promote an existing user", runAdmin},
+	"categories": {"categories list|create|update|move|delete [flags]", "Manage post categories", runCategories},
+	"posts":      {"posts export|import [flags]", "Export posts to or import them from JSON Lines", runPosts},
+	"search":     {"search reindex [flags]", "Rebuild the search index from posts and comments", runSearch},
+	"cache":      {"cache purge [-service name]", "Remove cached entries from Redis", runCache},
+	"comments":   {"comments list|moderate [flags]", "Review and moderate comments", runComments},
+	"health":     {"health", "Check the readiness of every service", runHealth},
+}
+
+// errUsage signals that the command line was invalid and usage was printed
+var errUsage = errors.New("invalid usage")
+
+func main() {
+	configPath := flag.String("config", defaultConfigPath(), "path to the JSON config file")
+	flag.Usage = usage
+	flag.Parse()
+
+	if flag.NArg() == 0 || flag.Arg(0) == "help" {
+		usage()
+		return
+	}
+
+	cmd, ok := commands[flag.Arg(0)]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "blogbish: unknown command %q\n\n", flag.Arg(0))
+		usage()
+		os.Exit(2)
+	}
+
+	required := os.Getenv("BLOGBISH_CONFIG") != ""
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "config" {
+			required = true
+		}
+	})
+
+	cfg, err := LoadConfig(*configPath, required)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "blogbish: %v\n", err)
+		os.Exit(1)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if err := cmd.run(ctx, newApp(cfg), flag.Args()[1:]); err != nil {
+		if errors.Is(err, errUsage) {
+			fmt.Fprintf(os.Stderr, "usage: blogbish %s\n", cmd.usage)
+			os.Exit(2)
+		}
+		fmt.Fprintf(os.Stderr, "blogbish: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: blogbish [-config path] <command> [arguments]\n\nCommands:\n")
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-12s %s\n", name, commands[name].help)
+	}
+
+	fmt.Fprintf(os.Stderr, "\nFlags:\n")
+	flag.PrintDefaults()
+}
+
+// subcommand splits args into a subcommand name and its arguments
+func subcommand(args []string) (string, []string) {
+	if len(args) == 0 {
+		return "", nil
+	}
+	return args[0], args[1:]
+}
+
+// newFlags returns a flag set that reports errors instead of exiting
+func newFlags(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	return fs
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/Thedrogon/blogbish/client"
+)
+
+// maxImportLine bounds a single JSON Lines record, matching the gateway's
+// default body limit
+const maxImportLine = 1 << 20
+
+// runPosts exports and imports posts as JSON Lines, one post per line, in
+// the format returned by the API
+func runPosts(ctx context.Context, app *app, args []string) error {
+	name, args := subcommand(args)
+	switch name {
+	case "export":
+		return postsExport(ctx, app, args)
+	case "import":
+		return postsImport(ctx, app, args)
+	}
+	return errUsage
+}
+
+func postsExport(ctx context.Context, app *app, args []string) error {
+	fs := newFlags("posts export")
+	output := fs.String("o", "-", "output file, - for stdout")
+	status := fs.String("status", "", "only export posts with this status")
+	category := fs.String("category", "", "only export posts in this category slug")
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+
+	api, err := app.client()
+	if err != nil {
+		return err
+	}
+
+	w := io.Writer(os.Stdout)
+	if *output != "-" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", *output, err)
+		}
+		defer file.Close()
+		w = file
+	}
+	buf := bufio.NewWriter(w)
+	enc := json.NewEncoder(buf)
+
+	count := 0
+	it := api.Posts.List(&client.PostFilter{Status: *status, Category: *category})
+	for it.Next(ctx) {
+		if err := enc.Encode(it.Item()); err != nil {
+			return fmt.Errorf("failed to write post: %w", err)
+		}
+		count++
+	}
+	if err := it.Err(); err != nil {
+		return fmt.Errorf("export stopped after %d posts: %w", count, err)
+	}
+	if err := buf.Flush(); err != nil {
+		return fmt.Errorf("failed to write posts: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "exported %d posts\n", count)
+	return nil
+}
+
+func postsImport(ctx context.Context, app *app, args []string) error {
+	fs := newFlags("posts import")
+	input := fs.String("i", "-", "input file, - for stdin")
+	skipErrors := fs.Bool("skip-errors", false, "report failed posts and carry on")
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+
+	api, err := app.client()
+	if err != nil {
+		return err
+	}
+
+	r := io.Reader(os.Stdin)
+	if *input != "-" {
+		file, err := os.Open(*input)
+		if err != nil {
+			return fmt.Errorf("failed to open %s: %w", *input, err)
+		}
+		defer file.Close()
+		r = file
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxImportLine)
+
+	imported, failed, line := 0, 0, 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		err := importPost(ctx, api, scanner.Bytes())
+		if err == nil {
+			imported++
+			continue
+		}
+		if !*skipErrors {
+			return fmt.Errorf("line %d: %w (imported %d posts)", line, err, imported)
+		}
+		failed++
+		fmt.Fprintf(os.Stderr, "line %d: %v\n", line, err)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read posts: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "imported %d posts, %d failed\n", imported, failed)
+	return nil
+}
+
+// importPost creates a post from an exported record. The API only creates
+// drafts or published posts, so archived posts are archived afterwards.
+func importPost(ctx context.Context, api *client.Client, record []byte) error {
+	var post client.Post
+	if err := json.Unmarshal(record, &post); err != nil {
+		return fmt.Errorf("invalid post: %w", err)
+	}
+
+	status := post.Status
+	if status != "published" {
+		status = "draft"
+	}
+	created, err := api.Posts.Create(ctx, &client.PostCreate{
+		Title:      post.Title,
+		Content:    post.Content,
+		CategoryID: post.CategoryID,
+		Tags:       post.Tags,
+		Status:     status,
+	})
+	if err != nil {
+		return err
+	}
+
+	if post.Status == "archived" {
+		if _, err := api.Posts.Update(ctx, created.Slug, &client.PostUpdate{Status: "archived"}); err != nil {
+			return fmt.Errorf("created post %s but failed to archive it: %w", created.Slug, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/Thedrogon/blogbish/client"
+)
+
+// runSearch rebuilds the search index. Posts and comments are read through
+// the gateway and written to the search service's internal index endpoints,
+// which the gateway does not expose.
+func runSearch(ctx context.Context, app *app, args []string) error {
+	name, args := subcommand(args)
+	if name != "reindex" {
+		return errUsage
+	}
+
+	fs := newFlags("search reindex")
+	only := fs.String("only", "", "reindex only \"posts\" or \"comments\"")
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+
+	api, err := app.client()
+	if err != nil {
+		return err
+	}
+
+	if *only == "" || *only == "posts" {
+		n, err := reindexPosts(ctx, app, api)
+		if err != nil {
+			return fmt.Errorf("reindexing posts stopped after %d: %w", n, err)
+		}
+		fmt.Fprintf(os.Stderr, "indexed %d posts\n", n)
+	}
+
+	if *only == "" || *only == "comments" {
+		n, err := reindexComments(ctx, app, api)
+		if err != nil {
+			return fmt.Errorf("reindexing comments stopped after %d: %w", n, err)
+		}
+		fmt.Fprintf(os.Stderr, "indexed %d comments\n", n)
+	}
+	return nil
+}
+
+func reindexPosts(ctx context.Context, app *app, api *client.Client) (int, error) {
+	categories, err := api.Categories.List(ctx)
+	if err != nil {
+		return 0, err
+	}
+	names := make(map[int64]string, len(categories))
+	for _, c := range categories {
+		names[c.ID] = c.Name
+	}
+
+	count := 0
+	it := api.Posts.List(nil)
+	for it.Next(ctx) {
+		post := it.Item()
+		doc := client.SearchPost{
+			ID:          strconv.FormatInt(post.ID, 10),
+			Title:       post.Title,
+			Content:     post.Content,
+			Excerpt:     post.Excerpt,
+			AuthorID:    post.AuthorID,
+			Tags:        post.Tags,
+			Status:      post.Status,
+			CreatedAt:   post.CreatedAt,
+			UpdatedAt:   post.UpdatedAt,
+			PublishedAt: post.PublishedAt,
+		}
+		if name, ok := names[post.CategoryID]; ok {
+			doc.Categories = []string{name}
+		}
+
+		if err := app.index(ctx, "/index/post", doc); err != nil {
+			return count, fmt.Errorf("post %d: %w", post.ID, err)
+		}
+		count++
+	}
+	return count, it.Err()
+}
+
+func reindexComments(ctx context.Context, app *app, api *client.Client) (int, error) {
+	count := 0
+	it := api.Comments.List(nil)
+	for it.Next(ctx) {
+		comment := it.Item()
+		doc := client.SearchComment{
+			ID:        comment.ID,
+			PostID:    comment.PostID,
+			Content:   comment.Content,
+			UserID:    comment.UserID,
+			Status:    comment.Status,
+			CreatedAt: comment.CreatedAt,
+			UpdatedAt: comment.UpdatedAt,
+		}
+
+		if err := app.index(ctx, "/index/comment", doc); err != nil {
+			return count, fmt.Errorf("comment %s: %w", comment.ID, err)
+		}
+		count++
+	}
+	return count, it.Err()
+}
+
+// index posts a document to the search service
+func (a *app) index(ctx context.Context, path string, doc interface{}) error {
+	body, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.cfg.Services.Search+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := a.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("search service returned %s: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+	return nil
+}
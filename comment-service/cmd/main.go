@@ -1,20 +1,51 @@
 package main
 
 import (
-	"database/sql"
+	"context"
 	"fmt"
-	"log"
+	"log/slog"
+	"net/http"
 	"os"
+	"time"
 
+	"github.com/Thedrogon/blogbish/Internals/auth/ginauth"
+	"github.com/Thedrogon/blogbish/Internals/health"
+	"github.com/Thedrogon/blogbish/Internals/logging"
+	"github.com/Thedrogon/blogbish/Internals/logging/ginlog"
+	"github.com/Thedrogon/blogbish/Internals/metrics"
+	"github.com/Thedrogon/blogbish/Internals/metrics/ginmetrics"
+	"github.com/Thedrogon/blogbish/Internals/openapi"
+	"github.com/Thedrogon/blogbish/Internals/tracing"
 	"github.com/Thedrogon/blogbish/comment-service/internal/handler"
 	"github.com/Thedrogon/blogbish/comment-service/internal/repository"
 	"github.com/Thedrogon/blogbish/comment-service/internal/service"
 	"github.com/Thedrogon/blogbish/comment-service/internal/websocket"
+	"github.com/XSAM/otelsql"
 	"github.com/gin-gonic/gin"
 	_ "github.com/lib/pq"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 )
 
 func main() {
+	logger := logging.New("comment-service")
+	if err := run(logger); err != nil {
+		logger.Error("service stopped", "error", err)
+		os.Exit(1)
+	}
+}
+
+// run starts the service and blocks until it has shut down. It returns
+// rather than exiting so deferred cleanup, such as flushing traces, still runs.
+func run(logger *slog.Logger) error {
+
+	// Initialize tracing
+	shutdownTracing, err := tracing.Init(context.Background(), "comment-service")
+	if err != nil {
+		return fmt.Errorf("error initializing tracing: %w", err)
+	}
+	defer shutdownTracing(context.Background())
+
 	// Initialize PostgreSQL connection
 	dbHost := os.Getenv("DB_HOST")
 	dbPort := os.Getenv("DB_PORT")
@@ -25,23 +56,28 @@ func main() {
 	psqlInfo := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
 		dbHost, dbPort, dbUser, dbPassword, dbName)
 
-	db, err := sql.Open("postgres", psqlInfo)
+	db, err := otelsql.Open("postgres", psqlInfo, otelsql.WithAttributes(semconv.DBSystemPostgreSQL))
 	if err != nil {
-		log.Fatalf("Error connecting to database: %v", err)
+		return fmt.Errorf("error connecting to database: %w", err)
 	}
 	defer db.Close()
 
 	// Test database connection
 	if err := db.Ping(); err != nil {
-		log.Fatalf("Error pinging database: %v", err)
+		return fmt.Errorf("error pinging database: %w", err)
 	}
+	metrics.RegisterDBStats(db, "blogbish")
+
+	// Readiness checks
+	checker := health.NewChecker(2 * time.Second)
+	checker.Add("postgres", db.PingContext)
 
 	// Initialize WebSocket hub
 	hub := websocket.NewHub()
 	go hub.Run()
 
 	// Initialize repository
-	commentRepo := repository.NewCommentRepository(db)
+	commentRepo := repository.NewPostgresRepository(db)
 
 	// Initialize service
 	commentService := service.NewCommentService(commentRepo, hub)
@@ -49,10 +85,26 @@ func main() {
 	// Initialize handler
 	commentHandler := handler.NewCommentHandler(commentService, hub)
 
+	// Tokens are issued by the auth service with the same secret
+	jwtSecret := os.Getenv("JWT_SECRET")
+	if jwtSecret == "" {
+		jwtSecret = "your-secret-key-here"
+	}
+
 	// Initialize router
-	router := gin.Default()
+	router := gin.New()
+	router.Use(otelgin.Middleware("comment-service"))
+	router.Use(ginlog.RequestID())
+	router.Use(ginlog.AccessLog(logger))
+	router.Use(ginmetrics.Middleware())
+	router.Use(gin.Recovery())
+	router.Use(ginauth.Authenticate(jwtSecret))
 
 	// Register routes
+	router.GET("/metrics", ginmetrics.Handler())
+	router.GET("/healthz", gin.WrapF(health.Liveness))
+	router.GET("/readyz", gin.WrapF(checker.Readiness))
+	router.GET("/openapi.json", gin.WrapF(openapi.Handler(handler.OpenAPI())))
 	router.POST("/comments", commentHandler.CreateComment)
 	router.GET("/comments/:id", commentHandler.GetComment)
 	router.PUT("/comments/:id", commentHandler.UpdateComment)
@@ -69,7 +121,17 @@ func main() {
 		port = "8083"
 	}
 
-	if err := router.Run(":" + port); err != nil {
-		log.Fatalf("Error starting server: %v", err)
+	srv := &http.Server{
+		Addr:         ":" + port,
+		Handler:      router,
+		IdleTimeout:  time.Minute,
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 30 * time.Second,
 	}
+
+	return health.Serve(srv, checker, logger,
+		// Hijacked WebSocket connections are not tracked by the HTTP server,
+		// so close them explicitly with a going-away frame
+		health.Closer{Name: "websocket hub", Close: hub.Shutdown},
+	)
 }
@@ -4,6 +4,8 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/Thedrogon/blogbish/Internals/problem"
+	"github.com/Thedrogon/blogbish/Internals/problem/ginproblem"
 	"github.com/Thedrogon/blogbish/comment-service/internal/models"
 	"github.com/Thedrogon/blogbish/comment-service/internal/service"
 	ws "github.com/Thedrogon/blogbish/comment-service/internal/websocket"
@@ -34,21 +36,21 @@ func NewCommentHandler(commentService *service.CommentService, hub *ws.Hub) *Com
 func (h *CommentHandler) CreateComment(c *gin.Context) {
 	var input models.CommentCreate
 	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input"})
+		ginproblem.BindError(c, err)
 		return
 	}
 
 	// Get user ID from context (set by auth middleware)
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		ginproblem.Error(c, http.StatusUnauthorized, problem.CodeUnauthorized, "authentication is required")
 		return
 	}
 	input.UserID = userID.(int64)
 
 	comment, err := h.commentService.CreateComment(c.Request.Context(), &input)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		ginproblem.WriteError(c, problems, err)
 		return
 	}
 
@@ -60,7 +62,7 @@ func (h *CommentHandler) GetComment(c *gin.Context) {
 
 	comment, err := h.commentService.GetComment(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Comment not found"})
+		ginproblem.WriteError(c, problems, err)
 		return
 	}
 
@@ -72,26 +74,19 @@ func (h *CommentHandler) UpdateComment(c *gin.Context) {
 
 	var input models.CommentUpdate
 	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input"})
+		ginproblem.BindError(c, err)
 		return
 	}
 
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		ginproblem.Error(c, http.StatusUnauthorized, problem.CodeUnauthorized, "authentication is required")
 		return
 	}
 
 	comment, err := h.commentService.UpdateComment(c.Request.Context(), id, userID.(int64), &input)
 	if err != nil {
-		switch err {
-		case service.ErrNotFound:
-			c.JSON(http.StatusNotFound, gin.H{"error": "Comment not found"})
-		case service.ErrForbidden:
-			c.JSON(http.StatusForbidden, gin.H{"error": "Not allowed to update this comment"})
-		default:
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		}
+		ginproblem.WriteError(c, problems, err)
 		return
 	}
 
@@ -103,19 +98,12 @@ func (h *CommentHandler) DeleteComment(c *gin.Context) {
 
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		ginproblem.Error(c, http.StatusUnauthorized, problem.CodeUnauthorized, "authentication is required")
 		return
 	}
 
 	if err := h.commentService.DeleteComment(c.Request.Context(), id, userID.(int64)); err != nil {
-		switch err {
-		case service.ErrNotFound:
-			c.JSON(http.StatusNotFound, gin.H{"error": "Comment not found"})
-		case service.ErrForbidden:
-			c.JSON(http.StatusForbidden, gin.H{"error": "Not allowed to delete this comment"})
-		default:
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		}
+		ginproblem.WriteError(c, problems, err)
 		return
 	}
 
@@ -131,7 +119,7 @@ func (h *CommentHandler) ListComments(c *gin.Context) {
 	if userID := c.Query("user_id"); userID != "" {
 		id, err := strconv.ParseInt(userID, 10, 64)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user_id"})
+			ginproblem.Error(c, http.StatusBadRequest, problem.CodeInvalidQuery, "user_id must be an integer")
 			return
 		}
 		filter.UserID = id
@@ -144,7 +132,7 @@ func (h *CommentHandler) ListComments(c *gin.Context) {
 	if page := c.Query("page"); page != "" {
 		p, err := strconv.Atoi(page)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page number"})
+			ginproblem.Error(c, http.StatusBadRequest, problem.CodeInvalidQuery, "page must be an integer")
 			return
 		}
 		filter.Page = p
@@ -153,30 +141,45 @@ func (h *CommentHandler) ListComments(c *gin.Context) {
 	if pageSize := c.Query("page_size"); pageSize != "" {
 		ps, err := strconv.Atoi(pageSize)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page size"})
+			ginproblem.Error(c, http.StatusBadRequest, problem.CodeInvalidQuery, "page_size must be an integer")
 			return
 		}
 		filter.PageSize = ps
 	}
 
-	comments, err := h.commentService.ListComments(c.Request.Context(), filter)
+	if token := c.Query("cursor"); token != "" {
+		cursor, err := models.DecodeCursor(token)
+		if err != nil {
+			ginproblem.Error(c, http.StatusBadRequest, problem.CodeInvalidQuery, "cursor is not valid")
+			return
+		}
+		if !cursor.Matches(filter) {
+			ginproblem.Error(c, http.StatusBadRequest, problem.CodeInvalidQuery, "cursor was issued for different filters")
+			return
+		}
+		filter.Cursor = cursor
+	}
+
+	result, err := h.commentService.ListComments(c.Request.Context(), filter)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		ginproblem.WriteError(c, problems, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, comments)
+	var next string
+	if result.Next != nil {
+		next = result.Next.Encode()
+	}
+	setPageHeaders(c, result.Total, next)
+
+	c.JSON(http.StatusOK, result.Comments)
 }
 
 func (h *CommentHandler) LikeComment(c *gin.Context) {
 	id := c.Param("id")
 
 	if err := h.commentService.LikeComment(c.Request.Context(), id); err != nil {
-		if err == service.ErrNotFound {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Comment not found"})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		ginproblem.WriteError(c, problems, err)
 		return
 	}
 
@@ -187,11 +190,7 @@ func (h *CommentHandler) ReportComment(c *gin.Context) {
 	id := c.Param("id")
 
 	if err := h.commentService.ReportComment(c.Request.Context(), id); err != nil {
-		if err == service.ErrNotFound {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Comment not found"})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		ginproblem.WriteError(c, problems, err)
 		return
 	}
 
@@ -203,16 +202,12 @@ func (h *CommentHandler) ModerateComment(c *gin.Context) {
 	status := c.Query("status")
 
 	if status == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Status is required"})
+		ginproblem.Error(c, http.StatusBadRequest, problem.CodeInvalidQuery, "status is required")
 		return
 	}
 
 	if err := h.commentService.ModerateComment(c.Request.Context(), id, status); err != nil {
-		if err == service.ErrNotFound {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Comment not found"})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		ginproblem.WriteError(c, problems, err)
 		return
 	}
 
@@ -222,19 +217,19 @@ func (h *CommentHandler) ModerateComment(c *gin.Context) {
 func (h *CommentHandler) WebSocket(c *gin.Context) {
 	postID := c.Query("post_id")
 	if postID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "post_id is required"})
+		ginproblem.Error(c, http.StatusBadRequest, problem.CodeInvalidQuery, "post_id is required")
 		return
 	}
 
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		ginproblem.Error(c, http.StatusUnauthorized, problem.CodeUnauthorized, "authentication is required")
 		return
 	}
 
+	// Upgrade writes its own error response when the handshake fails
 	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to upgrade connection"})
 		return
 	}
 
@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Thedrogon/blogbish/Internals/openapi"
+	"github.com/Thedrogon/blogbish/Internals/problem"
+	"github.com/Thedrogon/blogbish/comment-service/internal/models"
+)
+
+// OpenAPI describes the comment service API
+func OpenAPI() *openapi.Document {
+	b := openapi.NewBuilder("Comment Service", "1.0.0", "Threaded comments with likes, reports, moderation and live updates over WebSocket.")
+	fail := func(description string) openapi.Response { return b.Fails(description, problem.Problem{}) }
+
+	commentID := openapi.PathParam("id", "string", "Comment ID")
+
+	b.Add(http.MethodGet, "/comments", openapi.Operation{
+		OperationID: "listComments",
+		Summary:     "List comments",
+		Tags:        []string{"comments"},
+		Parameters: []openapi.Parameter{
+			openapi.QueryParam("post_id", "string", "Only comments on this post"),
+			openapi.QueryParam("user_id", "integer", "Only comments by this user"),
+			openapi.QueryParam("parent_id", "string", "Only replies to this comment"),
+			openapi.QueryParam("status", "string", "Comment status"),
+			openapi.QueryParam("cursor", "string", "Cursor of the page to fetch, from X-Next-Cursor or the next link"),
+			openapi.QueryParam("page", "integer", "Page number, starting at 1; ignored with a cursor"),
+			openapi.QueryParam("page_size", "integer", "Comments per page; all comments when omitted"),
+		},
+		Responses: map[string]openapi.Response{
+			"200": openapi.Paged(openapi.Returns("Comments, newest first", b.ArrayOf(models.CommentResponse{}))),
+			"400": fail("Invalid query parameter or cursor"),
+		},
+	})
+
+	b.Add(http.MethodPost, "/comments", openapi.Operation{
+		OperationID: "createComment",
+		Summary:     "Create a comment",
+		Tags:        []string{"comments"},
+		Security:    openapi.BearerAuth,
+		RequestBody: openapi.JSON(b.Ref(models.CommentCreate{})),
+		Responses: map[string]openapi.Response{
+			"201": openapi.Returns("Comment created", b.Ref(models.CommentResponse{})),
+			"400": fail("Malformed request body"),
+			"401": fail("Authentication required"),
+			"422": fail("Validation failed"),
+		},
+	})
+
+	b.Add(http.MethodGet, "/comments/{id}", openapi.Operation{
+		OperationID: "getComment",
+		Summary:     "Get a comment",
+		Tags:        []string{"comments"},
+		Parameters:  []openapi.Parameter{commentID},
+		Responses: map[string]openapi.Response{
+			"200": openapi.Returns("Comment", b.Ref(models.CommentResponse{})),
+			"404": fail("Comment not found"),
+		},
+	})
+
+	b.Add(http.MethodPut, "/comments/{id}", openapi.Operation{
+		OperationID: "updateComment",
+		Summary:     "Edit a comment",
+		Tags:        []string{"comments"},
+		Security:    openapi.BearerAuth,
+		Parameters:  []openapi.Parameter{commentID},
+		RequestBody: openapi.JSON(b.Ref(models.CommentUpdate{})),
+		Responses: map[string]openapi.Response{
+			"200": openapi.Returns("Comment updated", b.Ref(models.CommentResponse{})),
+			"403": fail("Not the author of the comment"),
+			"404": fail("Comment not found"),
+			"422": fail("Validation failed"),
+		},
+	})
+
+	b.Add(http.MethodDelete, "/comments/{id}", openapi.Operation{
+		OperationID: "deleteComment",
+		Summary:     "Delete a comment",
+		Tags:        []string{"comments"},
+		Security:    openapi.BearerAuth,
+		Parameters:  []openapi.Parameter{commentID},
+		Responses: map[string]openapi.Response{
+			"204": openapi.Returns("Comment deleted", nil),
+			"403": fail("Not the author of the comment"),
+			"404": fail("Comment not found"),
+		},
+	})
+
+	b.Add(http.MethodPost, "/comments/{id}/like", openapi.Operation{
+		OperationID: "likeComment",
+		Summary:     "Like a comment",
+		Tags:        []string{"comments"},
+		Parameters:  []openapi.Parameter{commentID},
+		Responses: map[string]openapi.Response{
+			"200": openapi.Returns("Like recorded", nil),
+			"404": fail("Comment not found"),
+		},
+	})
+
+	b.Add(http.MethodPost, "/comments/{id}/report", openapi.Operation{
+		OperationID: "reportComment",
+		Summary:     "Report a comment for moderation",
+		Tags:        []string{"comments"},
+		Parameters:  []openapi.Parameter{commentID},
+		Responses: map[string]openapi.Response{
+			"200": openapi.Returns("Report recorded", nil),
+			"404": fail("Comment not found"),
+		},
+	})
+
+	b.Add(http.MethodPut, "/comments/{id}/moderate", openapi.Operation{
+		OperationID: "moderateComment",
+		Summary:     "Set the moderation status of a comment",
+		Tags:        []string{"moderation"},
+		Security:    openapi.BearerAuth,
+		Parameters: []openapi.Parameter{
+			commentID,
+			{Name: "status", In: "query", Required: true, Description: "New status", Schema: &openapi.Schema{
+				Type: "string",
+				Enum: []string{"active", "deleted", "flagged", "hidden"},
+			}},
+		},
+		Responses: map[string]openapi.Response{
+			"200": openapi.Returns("Status updated", nil),
+			"400": fail("Missing status"),
+			"404": fail("Comment not found"),
+		},
+	})
+
+	b.Add(http.MethodGet, "/ws", openapi.Operation{
+		OperationID: "subscribeComments",
+		Summary:     "Subscribe to live comment events for a post over WebSocket",
+		Tags:        []string{"comments"},
+		Security:    openapi.BearerAuth,
+		Parameters: []openapi.Parameter{
+			{Name: "post_id", In: "query", Required: true, Description: "Post to follow", Schema: &openapi.Schema{Type: "string"}},
+		},
+		Responses: map[string]openapi.Response{
+			"101": {Description: "Switching to the WebSocket protocol; messages are WebSocketEvent objects"},
+			"400": fail("Missing post_id"),
+			"401": fail("Authentication required"),
+		},
+	})
+	// Events are not part of any HTTP body, so register their schema explicitly
+	b.Ref(models.WebSocketEvent{})
+
+	return b.Document()
+}
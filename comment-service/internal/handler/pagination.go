@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Headers describing a page of a listing
+const (
+	totalCountHeader = "X-Total-Count"
+	nextCursorHeader = "X-Next-Cursor"
+)
+
+// setPageHeaders reports the number of matching items and, when there is one,
+// the cursor of the next page, also as a Link rel="next". Links only carry a
+// query so they resolve against whatever path, such as the gateway's /v2, the
+// client used.
+func setPageHeaders(c *gin.Context, total int64, next string) {
+	c.Header(totalCountHeader, strconv.FormatInt(total, 10))
+
+	query := c.Request.URL.Query()
+	query.Del("page")
+	if query.Has("cursor") {
+		query.Del("cursor")
+		c.Writer.Header().Add("Link", fmt.Sprintf("<?%s>; rel=\"first\"", query.Encode()))
+	}
+
+	if next == "" {
+		return
+	}
+	c.Header(nextCursorHeader, next)
+	query.Set("cursor", next)
+	c.Writer.Header().Add("Link", fmt.Sprintf("<?%s>; rel=\"next\"", query.Encode()))
+}
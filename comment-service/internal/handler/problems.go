@@ -0,0 +1,14 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Thedrogon/blogbish/Internals/problem"
+	"github.com/Thedrogon/blogbish/comment-service/internal/service"
+)
+
+// problems maps domain errors to the problem returned to clients
+var problems = problem.Mappings{
+	{Err: service.ErrNotFound, Status: http.StatusNotFound, Code: problem.CodeNotFound, Detail: "comment not found"},
+	{Err: service.ErrForbidden, Status: http.StatusForbidden, Code: problem.CodeForbidden, Detail: "not allowed to modify this comment"},
+}
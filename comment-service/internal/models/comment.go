@@ -26,7 +26,7 @@ type Metadata struct {
 
 type CommentCreate struct {
 	PostID   string `json:"post_id" binding:"required"`
-	UserID   int64  `json:"user_id" binding:"required"`
+	UserID   int64  `json:"-"` // the authenticated caller
 	ParentID string `json:"parent_id,omitempty"`
 	Content  string `json:"content" binding:"required,min=1,max=5000"`
 }
@@ -55,6 +55,14 @@ type CommentFilter struct {
 	Status   string
 	Page     int
 	PageSize int
+
+	// Cursor continues a listing after a previous page and takes precedence
+	// over Page
+	Cursor *Cursor
+
+	// Lookahead fetches one row past the page, to tell whether another
+	// page follows, without moving the page offset
+	Lookahead bool
 }
 
 func (c *Comment) ToResponse() *CommentResponse {
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ErrInvalidCursor is returned for cursors that were not issued by the API
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor marks where a page of comments ends: the creation time and ID of its
+// last comment, and the filters it was issued for. Clients only ever see it
+// encoded, as an opaque token.
+type Cursor struct {
+	CreatedAt time.Time `json:"c"`
+	ID        string    `json:"i"`
+
+	// Filter is the Fingerprint of the filter the cursor was issued for
+	Filter string `json:"f"`
+}
+
+// CursorAfter returns the cursor continuing filter's listing after comment
+func CursorAfter(filter *CommentFilter, comment *Comment) *Cursor {
+	return &Cursor{CreatedAt: comment.CreatedAt, ID: comment.ID, Filter: filter.Fingerprint()}
+}
+
+// Matches reports whether c continues a listing of filter's comments
+func (c *Cursor) Matches(filter *CommentFilter) bool {
+	return c.Filter == filter.Fingerprint()
+}
+
+// Fingerprint identifies the comments filter selects, ignoring paging
+func (f *CommentFilter) Fingerprint() string {
+	data, _ := json.Marshal(struct {
+		PostID   string `json:"p,omitempty"`
+		UserID   int64  `json:"u,omitempty"`
+		ParentID string `json:"r,omitempty"`
+		Status   string `json:"s,omitempty"`
+	}{f.PostID, f.UserID, f.ParentID, f.Status})
+	sum := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(sum[:12])
+}
+
+// Encode returns the opaque token for c
+func (c *Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses a token returned by Encode
+func DecodeCursor(token string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var c Cursor
+	if err := json.Unmarshal(data, &c); err != nil || c.ID == "" {
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
+
+// CommentPage is one page of a comment listing
+type CommentPage struct {
+	Comments []*CommentResponse
+
+	// Total counts every comment matching the filter, across all pages
+	Total int64
+
+	// Next continues the listing; nil on the last page
+	Next *Cursor
+}
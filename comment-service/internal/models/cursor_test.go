@@ -0,0 +1,83 @@
+package models
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	filter := &CommentFilter{PostID: "p1", Status: "approved"}
+	comment := &Comment{ID: "c1", CreatedAt: time.Date(2024, 5, 6, 7, 8, 9, 123456789, time.UTC)}
+	cursor := CursorAfter(filter, comment)
+
+	decoded, err := DecodeCursor(cursor.Encode())
+	if err != nil {
+		t.Fatalf("DecodeCursor() error = %v", err)
+	}
+	if !decoded.CreatedAt.Equal(cursor.CreatedAt) || decoded.ID != cursor.ID || decoded.Filter != cursor.Filter {
+		t.Errorf("decoded %+v, want %+v", decoded, cursor)
+	}
+	if !decoded.Matches(filter) {
+		t.Error("cursor does not match the filter it was issued for")
+	}
+}
+
+func TestDecodeCursorTampered(t *testing.T) {
+	encode := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"not base64", "not a cursor!"},
+		{"not json", encode("cursor")},
+		{"missing id", encode(`{"c":"2024-01-01T00:00:00Z"}`)},
+		{"id of the wrong type", encode(`{"c":"2024-01-01T00:00:00Z","i":1}`)},
+		{"time not a time", encode(`{"c":"yesterday","i":"c1"}`)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if c, err := DecodeCursor(tt.token); err != ErrInvalidCursor {
+				t.Errorf("DecodeCursor() = %+v, %v; want ErrInvalidCursor", c, err)
+			}
+		})
+	}
+}
+
+func TestCursorMatches(t *testing.T) {
+	issued := &CommentFilter{PostID: "p1", UserID: 7, ParentID: "c0", Status: "approved", Page: 1, PageSize: 10}
+	cursor := CursorAfter(issued, &Comment{ID: "c1", CreatedAt: time.Now()})
+
+	tests := []struct {
+		name   string
+		change func(f *CommentFilter)
+		want   bool
+	}{
+		{"same filter", func(f *CommentFilter) {}, true},
+		{"paging changed", func(f *CommentFilter) { f.Page, f.PageSize = 2, 50 }, true},
+		{"post changed", func(f *CommentFilter) { f.PostID = "p2" }, false},
+		{"user dropped", func(f *CommentFilter) { f.UserID = 0 }, false},
+		{"parent changed", func(f *CommentFilter) { f.ParentID = "" }, false},
+		{"status changed", func(f *CommentFilter) { f.Status = "pending" }, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := *issued
+			tt.change(&filter)
+			if got := cursor.Matches(&filter); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	legacy, err := DecodeCursor(base64.RawURLEncoding.EncodeToString([]byte(`{"c":"2024-01-01T00:00:00Z","i":"c1"}`)))
+	if err != nil {
+		t.Fatalf("DecodeCursor() error = %v", err)
+	}
+	if legacy.Matches(issued) {
+		t.Error("cursor without a filter fingerprint matches")
+	}
+}
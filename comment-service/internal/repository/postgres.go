@@ -153,40 +153,33 @@ func (r *PostgresRepository) List(ctx context.Context, filter *models.CommentFil
 		FROM comments
 		WHERE 1=1
 	`
-	var args []interface{}
-	var conditions []string
-
-	if filter.PostID != "" {
-		args = append(args, filter.PostID)
-		conditions = append(conditions, fmt.Sprintf("post_id = $%d", len(args)))
-	}
-
-	if filter.UserID != 0 {
-		args = append(args, filter.UserID)
-		conditions = append(conditions, fmt.Sprintf("user_id = $%d", len(args)))
-	}
-
-	if filter.ParentID != "" {
-		args = append(args, filter.ParentID)
-		conditions = append(conditions, fmt.Sprintf("parent_id = $%d", len(args)))
-	}
+	conditions, args := commentConditions(filter)
 
-	if filter.Status != "" {
-		args = append(args, filter.Status)
-		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
-	} else {
-		conditions = append(conditions, "status != 'deleted'")
+	// Keyset pagination: only comments sorting after the cursor, so pages stay
+	// stable while comments are added
+	if filter.Cursor != nil {
+		args = append(args, filter.Cursor.CreatedAt, filter.Cursor.ID)
+		conditions = append(conditions, fmt.Sprintf("(created_at, id) < ($%d, $%d)", len(args)-1, len(args)))
 	}
 
 	for _, condition := range conditions {
 		query += " AND " + condition
 	}
 
-	query += " ORDER BY created_at DESC"
+	query += " ORDER BY created_at DESC, id DESC"
 
 	if filter.PageSize > 0 {
-		args = append(args, filter.PageSize, (filter.Page-1)*filter.PageSize)
-		query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+		limit := filter.PageSize
+		if filter.Lookahead {
+			limit++
+		}
+		args = append(args, limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+
+		if filter.Cursor == nil && filter.Page > 1 {
+			args = append(args, (filter.Page-1)*filter.PageSize)
+			query += fmt.Sprintf(" OFFSET $%d", len(args))
+		}
 	}
 
 	rows, err := r.db.QueryContext(ctx, query, args...)
@@ -225,6 +218,51 @@ func (r *PostgresRepository) List(ctx context.Context, filter *models.CommentFil
 	return comments, nil
 }
 
+func (r *PostgresRepository) Count(ctx context.Context, filter *models.CommentFilter) (int64, error) {
+	query := "SELECT COUNT(*) FROM comments WHERE 1=1"
+	conditions, args := commentConditions(filter)
+	for _, condition := range conditions {
+		query += " AND " + condition
+	}
+
+	var count int64
+	if err := r.db.QueryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count comments: %w", err)
+	}
+
+	return count, nil
+}
+
+// commentConditions translates filter into WHERE conditions and their arguments
+func commentConditions(filter *models.CommentFilter) ([]string, []interface{}) {
+	var args []interface{}
+	var conditions []string
+
+	if filter.PostID != "" {
+		args = append(args, filter.PostID)
+		conditions = append(conditions, fmt.Sprintf("post_id = $%d", len(args)))
+	}
+
+	if filter.UserID != 0 {
+		args = append(args, filter.UserID)
+		conditions = append(conditions, fmt.Sprintf("user_id = $%d", len(args)))
+	}
+
+	if filter.ParentID != "" {
+		args = append(args, filter.ParentID)
+		conditions = append(conditions, fmt.Sprintf("parent_id = $%d", len(args)))
+	}
+
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	} else {
+		conditions = append(conditions, "status != 'deleted'")
+	}
+
+	return conditions, args
+}
+
 func (r *PostgresRepository) GetChildren(ctx context.Context, parentID string) ([]*models.Comment, error) {
 	query := `
 		SELECT id, post_id, user_id, parent_id, content, status,
@@ -22,6 +22,9 @@ type CommentRepository interface {
 	// List retrieves comments based on filters
 	List(ctx context.Context, filter *models.CommentFilter) ([]*models.Comment, error)
 
+	// Count returns the number of comments matching filter, ignoring pagination
+	Count(ctx context.Context, filter *models.CommentFilter) (int64, error)
+
 	// GetChildren retrieves all child comments for a parent comment
 	GetChildren(ctx context.Context, parentID string) ([]*models.Comment, error)
 
@@ -123,18 +123,36 @@ func (s *CommentService) DeleteComment(ctx context.Context, id string, userID in
 	return nil
 }
 
-func (s *CommentService) ListComments(ctx context.Context, filter *models.CommentFilter) ([]*models.CommentResponse, error) {
-	comments, err := s.repo.List(ctx, filter)
+// ListComments returns a page of comments matching filter, newest first. The
+// page carries the total number of matches and, unless it is the last one,
+// the cursor of the next page.
+func (s *CommentService) ListComments(ctx context.Context, filter *models.CommentFilter) (*models.CommentPage, error) {
+	// Fetch one comment more than asked for to learn whether another page follows
+	query := *filter
+	query.Lookahead = true
+
+	comments, err := s.repo.List(ctx, &query)
 	if err != nil {
 		return nil, err
 	}
 
-	responses := make([]*models.CommentResponse, len(comments))
+	total, err := s.repo.Count(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &models.CommentPage{Total: total}
+	if filter.PageSize > 0 && len(comments) > filter.PageSize {
+		comments = comments[:filter.PageSize]
+		page.Next = models.CursorAfter(filter, comments[len(comments)-1])
+	}
+
+	page.Comments = make([]*models.CommentResponse, len(comments))
 	for i, comment := range comments {
-		responses[i] = comment.ToResponse()
+		page.Comments[i] = comment.ToResponse()
 	}
 
-	return responses, nil
+	return page, nil
 }
 
 func (s *CommentService) LikeComment(ctx context.Context, id string) error {
@@ -1,13 +1,18 @@
 package websocket
 
 import (
+	"context"
 	"encoding/json"
 	"log"
 	"sync"
+	"time"
 
 	"github.com/gorilla/websocket"
 )
 
+// writeWait bounds how long the close frame may take to write
+const writeWait = 5 * time.Second
+
 type Client struct {
 	Hub      *Hub
 	Conn     *websocket.Conn
@@ -16,9 +21,14 @@ type Client struct {
 	UserID   int64
 	closeMux sync.Mutex
 	closed   bool
+
+	// goingAway is set before Send is closed during shutdown so the write
+	// pump sends a 1001 close frame instead of a normal closure
+	goingAway bool
 }
 
 func NewClient(hub *Hub, conn *websocket.Conn, postID string, userID int64) *Client {
+	hub.pumps.Add(1)
 	return &Client{
 		Hub:      hub,
 		Conn:     conn,
@@ -43,6 +53,15 @@ type Hub struct {
 	// Unregister requests from clients
 	Unregister chan *Client
 
+	// Shutdown requests
+	shutdown chan struct{}
+
+	// Tracks running write pumps so Shutdown can wait for close frames to be sent
+	pumps sync.WaitGroup
+
+	// Set once Shutdown has been requested; new clients are turned away
+	stopped bool
+
 	// Mutex for thread-safe operations
 	mu sync.RWMutex
 }
@@ -53,6 +72,7 @@ func NewHub() *Hub {
 		broadcast:  make(chan []byte),
 		Register:   make(chan *Client),
 		Unregister: make(chan *Client),
+		shutdown:   make(chan struct{}),
 	}
 }
 
@@ -61,11 +81,18 @@ func (h *Hub) Run() {
 		select {
 		case client := <-h.Register:
 			h.mu.Lock()
+			if h.stopped {
+				client.goingAway = true
+				close(client.Send)
+				h.mu.Unlock()
+				continue
+			}
 			if _, ok := h.clients[client.PostID]; !ok {
 				h.clients[client.PostID] = make(map[*Client]bool)
 			}
 			h.clients[client.PostID][client] = true
 			h.mu.Unlock()
+			connections.Inc()
 
 		case client := <-h.Unregister:
 			h.mu.Lock()
@@ -76,10 +103,24 @@ func (h *Hub) Run() {
 					if len(clients) == 0 {
 						delete(h.clients, client.PostID)
 					}
+					connections.Dec()
 				}
 			}
 			h.mu.Unlock()
 
+		case <-h.shutdown:
+			h.mu.Lock()
+			h.stopped = true
+			for postID, clients := range h.clients {
+				for client := range clients {
+					client.goingAway = true
+					close(client.Send)
+					connections.Dec()
+				}
+				delete(h.clients, postID)
+			}
+			h.mu.Unlock()
+
 		case message := <-h.broadcast:
 			var event struct {
 				PostID string `json:"post_id"`
@@ -104,6 +145,8 @@ func (h *Hub) Run() {
 						delete(h.clients, client.PostID)
 					}
 					h.mu.Unlock()
+					messagesDropped.Inc()
+					connections.Dec()
 				}
 			}
 		}
@@ -134,6 +177,7 @@ func (c *Client) ReadPump() {
 
 func (c *Client) WritePump() {
 	defer func() {
+		c.Hub.pumps.Done()
 		c.closeMux.Lock()
 		if !c.closed {
 			c.Conn.Close()
@@ -146,7 +190,12 @@ func (c *Client) WritePump() {
 		select {
 		case message, ok := <-c.Send:
 			if !ok {
-				c.Conn.WriteMessage(websocket.CloseMessage, []byte{})
+				code := websocket.CloseNormalClosure
+				if c.goingAway {
+					code = websocket.CloseGoingAway
+				}
+				c.Conn.WriteControl(websocket.CloseMessage,
+					websocket.FormatCloseMessage(code, ""), time.Now().Add(writeWait))
 				return
 			}
 
@@ -172,3 +221,26 @@ func (h *Hub) BroadcastToPost(postID string, event interface{}) error {
 	h.broadcast <- data
 	return nil
 }
+
+// Shutdown sends a going-away close frame to every connected client and
+// waits for their write pumps to finish or for ctx to expire
+func (h *Hub) Shutdown(ctx context.Context) error {
+	select {
+	case h.shutdown <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		h.pumps.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,23 @@
+package websocket
+
+import (
+	"github.com/Thedrogon/blogbish/Internals/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// connections tracks clients currently registered with the hub
+	connections = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: metrics.Namespace,
+		Name:      "websocket_connections",
+		Help:      "Number of open WebSocket connections.",
+	})
+
+	// messagesDropped counts broadcasts dropped because a client's send buffer was full
+	messagesDropped = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Name:      "websocket_messages_dropped_total",
+		Help:      "Total number of WebSocket messages dropped for slow clients.",
+	})
+)
@@ -1,24 +1,51 @@
 package main
 
 import (
-	//"context"
+	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 	"os"
+	"time"
 
+	"github.com/Thedrogon/blogbish/Internals/auth/ginauth"
+	"github.com/Thedrogon/blogbish/Internals/health"
+	"github.com/Thedrogon/blogbish/Internals/logging"
+	"github.com/Thedrogon/blogbish/Internals/logging/ginlog"
+	"github.com/Thedrogon/blogbish/Internals/metrics/ginmetrics"
+	"github.com/Thedrogon/blogbish/Internals/openapi"
+	"github.com/Thedrogon/blogbish/Internals/tracing"
 	"github.com/Thedrogon/blogbish/media-service/internal/cache"
 	"github.com/Thedrogon/blogbish/media-service/internal/handler"
 	"github.com/Thedrogon/blogbish/media-service/internal/service"
 	"github.com/Thedrogon/blogbish/media-service/internal/storage"
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 )
 
 func main() {
+	logger := logging.New("media-service")
+	if err := run(logger); err != nil {
+		logger.Error("service stopped", "error", err)
+		os.Exit(1)
+	}
+}
+
+// run starts the service and blocks until it has shut down. It returns
+// rather than exiting so deferred cleanup, such as flushing traces, still runs.
+func run(logger *slog.Logger) error {
+
+	// Initialize tracing
+	shutdownTracing, err := tracing.Init(context.Background(), "media-service")
+	if err != nil {
+		return fmt.Errorf("failed to initialize tracing: %w", err)
+	}
+	defer shutdownTracing(context.Background())
+
 	// Initialize MinIO storage
 	storageProvider, err := storage.NewMinIOStorage()
 	if err != nil {
-		log.Fatalf("Failed to initialize MinIO storage: %v", err)
+		return fmt.Errorf("failed to initialize MinIO storage: %w", err)
 	}
 
 	// Initialize Redis cache
@@ -27,22 +54,44 @@ func main() {
 		os.Getenv("REDIS_PASSWORD"),
 	)
 	if err != nil {
-		log.Fatalf("Failed to initialize Redis cache: %v", err)
+		return fmt.Errorf("failed to initialize Redis cache: %w", err)
 	}
 
+	// Readiness checks
+	checker := health.NewChecker(2 * time.Second)
+	checker.Add("redis", redisCache.Ping)
+	checker.Add("minio", storageProvider.Ping)
+
 	// Initialize services
 	mediaService := service.NewMediaService(storageProvider, redisCache)
 
 	// Initialize handlers
 	mediaHandler := handler.NewMediaHandler(mediaService)
 
+	// Tokens are issued by the auth service with the same secret
+	jwtSecret := os.Getenv("JWT_SECRET")
+	if jwtSecret == "" {
+		jwtSecret = "your-secret-key-here"
+	}
+
 	// Initialize Gin router
-	router := gin.Default()
+	router := gin.New()
+	router.Use(otelgin.Middleware("media-service"))
+	router.Use(ginlog.RequestID())
+	router.Use(ginlog.AccessLog(logger))
+	router.Use(ginmetrics.Middleware())
+	router.Use(gin.Recovery())
 
 	// Configure CORS
 	router.Use(corsMiddleware())
+	router.Use(ginauth.Authenticate(jwtSecret))
 
 	// Configure routes
+	router.GET("/metrics", ginmetrics.Handler())
+	router.GET("/healthz", gin.WrapF(health.Liveness))
+	router.GET("/readyz", gin.WrapF(checker.Readiness))
+	router.GET("/openapi.json", gin.WrapF(openapi.Handler(handler.OpenAPI())))
+
 	api := router.Group("/api/v1")
 	{
 		media := api.Group("/media")
@@ -61,17 +110,25 @@ func main() {
 		port = "8082"
 	}
 
-	log.Printf("Media service started on port %s", port)
-	if err := router.Run(":" + port); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+	srv := &http.Server{
+		Addr:         ":" + port,
+		Handler:      router,
+		IdleTimeout:  time.Minute,
+		ReadTimeout:  5 * time.Minute,
+		WriteTimeout: 5 * time.Minute,
 	}
+
+	return health.Serve(srv, checker, logger,
+		health.Closer{Name: "redis", Close: func(context.Context) error { return redisCache.Close() }},
+	)
 }
 
 func corsMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
 		c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, "+logging.RequestIDHeader)
+		c.Writer.Header().Set("Access-Control-Expose-Headers", logging.RequestIDHeader)
 
 		if c.Request.Method == http.MethodOptions {
 			c.AbortWithStatus(http.StatusOK)
@@ -6,12 +6,15 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/Thedrogon/blogbish/Internals/cachekeys"
+	"github.com/Thedrogon/blogbish/Internals/metrics"
 	"github.com/Thedrogon/blogbish/media-service/internal/models"
+	"github.com/redis/go-redis/extra/redisotel/v9"
 	"github.com/redis/go-redis/v9"
 )
 
 const (
-	mediaKeyPrefix    = "media:"
+	mediaKeyPrefix    = cachekeys.Media
 	defaultExpiration = 24 * time.Hour
 )
 
@@ -26,6 +29,10 @@ func NewRedisCache(addr, password string) (*RedisCache, error) {
 		DB:       0,
 	})
 
+	if err := redisotel.InstrumentTracing(client); err != nil {
+		return nil, fmt.Errorf("failed to instrument Redis client: %w", err)
+	}
+
 	// Test connection
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -41,6 +48,7 @@ func (c *RedisCache) GetMedia(ctx context.Context, id string) (*models.Media, er
 	key := mediaKeyPrefix + id
 	data, err := c.client.Get(ctx, key).Bytes()
 	if err == redis.Nil {
+		metrics.CacheMiss("media")
 		return nil, fmt.Errorf("media not found in cache")
 	}
 	if err != nil {
@@ -52,6 +60,7 @@ func (c *RedisCache) GetMedia(ctx context.Context, id string) (*models.Media, er
 		return nil, fmt.Errorf("failed to unmarshal media: %w", err)
 	}
 
+	metrics.CacheHit("media")
 	return &media, nil
 }
 
@@ -76,3 +85,13 @@ func (c *RedisCache) DeleteMedia(ctx context.Context, id string) error {
 	}
 	return nil
 }
+
+// Ping checks that Redis is reachable
+func (c *RedisCache) Ping(ctx context.Context) error {
+	return c.client.Ping(ctx).Err()
+}
+
+// Close releases the underlying Redis connections
+func (c *RedisCache) Close() error {
+	return c.client.Close()
+}
@@ -5,6 +5,8 @@ import (
 	"net/http"
 	"path/filepath"
 
+	"github.com/Thedrogon/blogbish/Internals/problem"
+	"github.com/Thedrogon/blogbish/Internals/problem/ginproblem"
 	"github.com/Thedrogon/blogbish/media-service/internal/models"
 	"github.com/Thedrogon/blogbish/media-service/internal/service"
 	"github.com/gin-gonic/gin"
@@ -25,7 +27,7 @@ func (h *MediaHandler) Upload(c *gin.Context) {
 	// Parse multipart form
 	file, header, err := c.Request.FormFile("file")
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "No file provided"})
+		ginproblem.Error(c, http.StatusBadRequest, problem.CodeInvalidBody, "a file must be provided in the \"file\" form field")
 		return
 	}
 	defer file.Close()
@@ -33,7 +35,7 @@ func (h *MediaHandler) Upload(c *gin.Context) {
 	// Get user ID from context (set by auth middleware)
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		ginproblem.Error(c, http.StatusUnauthorized, problem.CodeUnauthorized, "authentication is required")
 		return
 	}
 
@@ -52,7 +54,7 @@ func (h *MediaHandler) Upload(c *gin.Context) {
 	// Upload file
 	media, err := h.mediaService.UploadFile(c.Request.Context(), file, filename, header.Header.Get("Content-Type"), upload)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		ginproblem.WriteError(c, problems, err)
 		return
 	}
 
@@ -64,13 +66,13 @@ func (h *MediaHandler) Download(c *gin.Context) {
 
 	media, err := h.mediaService.GetFile(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "File not found"})
+		ginproblem.WriteError(c, problems, err)
 		return
 	}
 
 	reader, err := h.mediaService.DownloadFile(c.Request.Context(), media.Path)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to download file"})
+		ginproblem.WriteError(c, problems, err)
 		return
 	}
 	defer reader.Close()
@@ -86,13 +88,13 @@ func (h *MediaHandler) Delete(c *gin.Context) {
 	// Get user ID from context
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		ginproblem.Error(c, http.StatusUnauthorized, problem.CodeUnauthorized, "authentication is required")
 		return
 	}
 
 	// Delete file
 	if err := h.mediaService.DeleteFile(c.Request.Context(), id, userID.(int64)); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		ginproblem.WriteError(c, problems, err)
 		return
 	}
 
@@ -104,7 +106,7 @@ func (h *MediaHandler) GetMetadata(c *gin.Context) {
 
 	media, err := h.mediaService.GetFile(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "File not found"})
+		ginproblem.WriteError(c, problems, err)
 		return
 	}
 
@@ -117,19 +119,19 @@ func (h *MediaHandler) UpdateMetadata(c *gin.Context) {
 	// Get user ID from context
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		ginproblem.Error(c, http.StatusUnauthorized, problem.CodeUnauthorized, "authentication is required")
 		return
 	}
 
 	var metadata models.Metadata
 	if err := c.ShouldBindJSON(&metadata); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid metadata"})
+		ginproblem.BindError(c, err)
 		return
 	}
 
 	media, err := h.mediaService.UpdateMetadata(c.Request.Context(), id, userID.(int64), &metadata)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		ginproblem.WriteError(c, problems, err)
 		return
 	}
 
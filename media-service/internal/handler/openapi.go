@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Thedrogon/blogbish/Internals/openapi"
+	"github.com/Thedrogon/blogbish/Internals/problem"
+	"github.com/Thedrogon/blogbish/media-service/internal/models"
+)
+
+// OpenAPI describes the media service API
+func OpenAPI() *openapi.Document {
+	b := openapi.NewBuilder("Media Service", "1.0.0", "Media uploads, downloads and metadata.")
+	fail := func(description string) openapi.Response { return b.Fails(description, problem.Problem{}) }
+
+	mediaID := openapi.PathParam("id", "string", "Media ID")
+
+	b.Add(http.MethodPost, "/api/v1/media/upload", openapi.Operation{
+		OperationID: "uploadMedia",
+		Summary:     "Upload a file",
+		Tags:        []string{"media"},
+		Security:    openapi.BearerAuth,
+		RequestBody: &openapi.RequestBody{
+			Required: true,
+			Content: map[string]openapi.MediaType{"multipart/form-data": {Schema: &openapi.Schema{
+				Type: "object",
+				Properties: map[string]*openapi.Schema{
+					"file":        {Type: "string", Format: "binary"},
+					"title":       {Type: "string"},
+					"description": {Type: "string"},
+					"alt_text":    {Type: "string"},
+				},
+				Required: []string{"file"},
+			}}},
+		},
+		Responses: map[string]openapi.Response{
+			"201": openapi.Returns("File uploaded", b.Ref(models.MediaResponse{})),
+			"400": fail("No file provided"),
+			"401": fail("Authentication required"),
+		},
+	})
+
+	b.Add(http.MethodGet, "/api/v1/media/{id}", openapi.Operation{
+		OperationID: "getMedia",
+		Summary:     "Get media details",
+		Tags:        []string{"media"},
+		Parameters:  []openapi.Parameter{mediaID},
+		Responses: map[string]openapi.Response{
+			"200": openapi.Returns("Media", b.Ref(models.Media{})),
+			"404": fail("Media not found"),
+		},
+	})
+
+	b.Add(http.MethodGet, "/api/v1/media/{id}/download", openapi.Operation{
+		OperationID: "downloadMedia",
+		Summary:     "Download the file",
+		Tags:        []string{"media"},
+		Parameters:  []openapi.Parameter{mediaID},
+		Responses: map[string]openapi.Response{
+			"200": {
+				Description: "File contents",
+				Content: map[string]openapi.MediaType{"application/octet-stream": {
+					Schema: &openapi.Schema{Type: "string", Format: "binary"},
+				}},
+			},
+			"404": fail("Media not found"),
+		},
+	})
+
+	b.Add(http.MethodPut, "/api/v1/media/{id}/metadata", openapi.Operation{
+		OperationID: "updateMediaMetadata",
+		Summary:     "Update media metadata",
+		Tags:        []string{"media"},
+		Security:    openapi.BearerAuth,
+		Parameters:  []openapi.Parameter{mediaID},
+		RequestBody: openapi.JSON(b.Ref(models.Metadata{})),
+		Responses: map[string]openapi.Response{
+			"200": openapi.Returns("Metadata updated", b.Ref(models.MediaResponse{})),
+			"403": fail("Not the owner of the file"),
+			"404": fail("Media not found"),
+		},
+	})
+
+	b.Add(http.MethodDelete, "/api/v1/media/{id}", openapi.Operation{
+		OperationID: "deleteMedia",
+		Summary:     "Delete a file",
+		Tags:        []string{"media"},
+		Security:    openapi.BearerAuth,
+		Parameters:  []openapi.Parameter{mediaID},
+		Responses: map[string]openapi.Response{
+			"204": openapi.Returns("File deleted", nil),
+			"403": fail("Not the owner of the file"),
+			"404": fail("Media not found"),
+		},
+	})
+
+	return b.Document()
+}
@@ -29,11 +29,15 @@ func NewMediaService(storage storage.Storage, cache cache.Cache) *MediaService {
 }
 
 func (s *MediaService) UploadFile(ctx context.Context, file io.Reader, filename string, contentType string, upload *models.MediaUpload) (*models.MediaResponse, error) {
-	// Upload file to storage
-	path, err := s.storage.Upload(ctx, file, filename, contentType)
+	// Upload file to storage, counting the bytes as they stream through
+	counter := &countingReader{r: file}
+	path, err := s.storage.Upload(ctx, counter, filename, contentType)
 	if err != nil {
+		uploads.WithLabelValues("error").Inc()
 		return nil, err
 	}
+	uploads.WithLabelValues("success").Inc()
+	uploadBytes.Add(float64(counter.n))
 
 	// Create media record
 	media := &models.Media{
@@ -41,6 +45,7 @@ func (s *MediaService) UploadFile(ctx context.Context, file io.Reader, filename
 		UserID:      upload.UserID,
 		Filename:    filename,
 		ContentType: contentType,
+		Size:        counter.n,
 		Path:        path,
 		URL:         s.storage.GetURL(path),
 		Metadata: models.Metadata{
@@ -127,3 +132,15 @@ func (s *MediaService) UpdateMetadata(ctx context.Context, id string, userID int
 
 	return media.ToResponse(), nil
 }
+
+// countingReader counts the bytes read from the wrapped reader
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
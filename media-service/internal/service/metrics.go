@@ -0,0 +1,23 @@
+package service
+
+import (
+	"github.com/Thedrogon/blogbish/Internals/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// uploadBytes counts bytes written to object storage
+	uploadBytes = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Name:      "media_upload_bytes_total",
+		Help:      "Total number of bytes uploaded to storage.",
+	})
+
+	// uploads counts upload attempts by result
+	uploads = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Name:      "media_uploads_total",
+		Help:      "Total number of media uploads by result.",
+	}, []string{"result"})
+)
@@ -1,17 +1,14 @@
 package storage
 
 import (
-	"bytes"
 	"context"
 	"fmt"
 	"io"
-	"mime/multipart"
 	"os"
-	"path/filepath"
-	"time"
 
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
 type MinIOStorage struct {
@@ -27,9 +24,15 @@ func NewMinIOStorage() (*MinIOStorage, error) {
 	useSSL := os.Getenv("MINIO_USE_SSL") == "true"
 
 	// Initialize MinIO client
+	transport, err := minio.DefaultTransport(useSSL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create MinIO transport: %v", err)
+	}
+
 	client, err := minio.New(endpoint, &minio.Options{
-		Creds:  credentials.NewStaticV4(accessKey, secretKey, ""),
-		Secure: useSSL,
+		Creds:     credentials.NewStaticV4(accessKey, secretKey, ""),
+		Secure:    useSSL,
+		Transport: otelhttp.NewTransport(transport),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create MinIO client: %v", err)
@@ -55,20 +58,9 @@ func NewMinIOStorage() (*MinIOStorage, error) {
 	}, nil
 }
 
-func (s *MinIOStorage) Upload(ctx context.Context, file *multipart.FileHeader) (string, error) {
-	// Open the uploaded file
-	src, err := file.Open()
-	if err != nil {
-		return "", fmt.Errorf("failed to open file: %v", err)
-	}
-	defer src.Close()
-
-	// Generate a unique filename
-	filename := fmt.Sprintf("%d-%s", time.Now().UnixNano(), filepath.Base(file.Filename))
-	contentType := file.Header.Get("Content-Type")
-
-	// Upload the file to MinIO
-	_, err = s.client.PutObject(ctx, s.bucketName, filename, src, file.Size, minio.PutObjectOptions{
+func (s *MinIOStorage) Upload(ctx context.Context, file io.Reader, filename string, contentType string) (string, error) {
+	// The size is unknown while streaming, so MinIO uploads in parts
+	_, err := s.client.PutObject(ctx, s.bucketName, filename, file, -1, minio.PutObjectOptions{
 		ContentType: contentType,
 	})
 	if err != nil {
@@ -78,27 +70,20 @@ func (s *MinIOStorage) Upload(ctx context.Context, file *multipart.FileHeader) (
 	return filename, nil
 }
 
-func (s *MinIOStorage) Download(ctx context.Context, filename string) ([]byte, string, error) {
-	// Get the object
+func (s *MinIOStorage) Download(ctx context.Context, filename string) (io.ReadCloser, error) {
 	object, err := s.client.GetObject(ctx, s.bucketName, filename, minio.GetObjectOptions{})
 	if err != nil {
-		return nil, "", fmt.Errorf("failed to get object: %v", err)
+		return nil, fmt.Errorf("failed to get object: %v", err)
 	}
-	defer object.Close()
 
-	// Get object info
-	info, err := object.Stat()
-	if err != nil {
-		return nil, "", fmt.Errorf("failed to get object info: %v", err)
+	// GetObject is lazy; stat it so a missing object fails here rather than
+	// halfway through the response
+	if _, err := object.Stat(); err != nil {
+		object.Close()
+		return nil, fmt.Errorf("failed to get object info: %v", err)
 	}
 
-	// Read the object data
-	buffer := bytes.NewBuffer(nil)
-	if _, err := io.Copy(buffer, object); err != nil {
-		return nil, "", fmt.Errorf("failed to read object data: %v", err)
-	}
-
-	return buffer.Bytes(), info.ContentType, nil
+	return object, nil
 }
 
 func (s *MinIOStorage) Delete(ctx context.Context, filename string) error {
@@ -111,4 +96,15 @@ func (s *MinIOStorage) Delete(ctx context.Context, filename string) error {
 
 func (s *MinIOStorage) GetURL(filename string) string {
 	return fmt.Sprintf("/api/v1/media/%s", filename)
-} 
\ No newline at end of file
+} 
+
+func (s *MinIOStorage) Ping(ctx context.Context) error {
+	exists, err := s.client.BucketExists(ctx, s.bucketName)
+	if err != nil {
+		return fmt.Errorf("failed to check bucket existence: %v", err)
+	}
+	if !exists {
+		return fmt.Errorf("bucket %s does not exist", s.bucketName)
+	}
+	return nil
+}
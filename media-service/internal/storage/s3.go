@@ -74,3 +74,13 @@ func (s *S3Storage) Delete(ctx context.Context, filepath string) error {
 func (s *S3Storage) GetURL(filepath string) string {
 	return path.Join(s.baseURL, filepath)
 }
+
+func (s *S3Storage) Ping(ctx context.Context) error {
+	_, err := s.client.HeadBucket(ctx, &s3.HeadBucketInput{
+		Bucket: aws.String(s.bucketName),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to access S3 bucket: %w", err)
+	}
+	return nil
+}
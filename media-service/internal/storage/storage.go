@@ -17,4 +17,7 @@ type Storage interface {
 
 	// GetURL returns the public URL for a file
 	GetURL(path string) string
+
+	// Ping checks that the storage backend and bucket are reachable
+	Ping(ctx context.Context) error
 }
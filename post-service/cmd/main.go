@@ -2,26 +2,45 @@ package main
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 	"os"
-	"os/signal"
-	"syscall"
 	"time"
 
+	"github.com/Thedrogon/blogbish/Internals/auth"
+	"github.com/Thedrogon/blogbish/Internals/health"
+	"github.com/Thedrogon/blogbish/Internals/logging"
+	"github.com/Thedrogon/blogbish/Internals/metrics"
+	"github.com/Thedrogon/blogbish/Internals/openapi"
+	"github.com/Thedrogon/blogbish/Internals/tracing"
 	"github.com/Thedrogon/blogbish/post-service/internal/cache"
 	"github.com/Thedrogon/blogbish/post-service/internal/handler"
+	"github.com/Thedrogon/blogbish/post-service/internal/links"
 	"github.com/Thedrogon/blogbish/post-service/internal/repository"
+	"github.com/Thedrogon/blogbish/post-service/internal/scheduler"
 	"github.com/Thedrogon/blogbish/post-service/internal/service"
+	"github.com/XSAM/otelsql"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
 	_ "github.com/lib/pq"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 )
 
 func main() {
+	logger := logging.New("post-service")
+	if err := run(logger); err != nil {
+		logger.Error("service stopped", "error", err)
+		os.Exit(1)
+	}
+}
+
+// run starts the service and blocks until it has shut down. It returns
+// rather than exiting so deferred cleanup, such as flushing traces, still runs.
+func run(logger *slog.Logger) error {
+
 	// Database configuration
 	dbHost := getEnv("DB_HOST", "localhost")
 	dbPort := getEnv("DB_PORT", "5432")
@@ -34,19 +53,33 @@ func main() {
 	redisPort := getEnv("REDIS_PORT", "6379")
 	redisPassword := getEnv("REDIS_PASSWORD", "")
 
+	// Public URLs of posts and media point at the gateway
+	publicURL := getEnv("PUBLIC_URL", "http://localhost:8000")
+
+	// Tokens are issued by the auth service with the same secret
+	jwtSecret := getEnv("JWT_SECRET", "your-secret-key-here")
+
+	// Initialize tracing
+	shutdownTracing, err := tracing.Init(context.Background(), "post-service")
+	if err != nil {
+		return fmt.Errorf("error initializing tracing: %w", err)
+	}
+	defer shutdownTracing(context.Background())
+
 	// Connect to PostgreSQL
 	psqlInfo := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
 		dbHost, dbPort, dbUser, dbPassword, dbName)
 
-	db, err := sql.Open("postgres", psqlInfo)
+	db, err := otelsql.Open("postgres", psqlInfo, otelsql.WithAttributes(semconv.DBSystemPostgreSQL))
 	if err != nil {
-		log.Fatalf("Error connecting to database: %v", err)
+		return fmt.Errorf("error connecting to database: %w", err)
 	}
 	defer db.Close()
 
 	if err = db.Ping(); err != nil {
-		log.Fatalf("Error pinging database: %v", err)
+		return fmt.Errorf("error pinging database: %w", err)
 	}
+	metrics.RegisterDBStats(db, "blogbish")
 
 	// Initialize Redis cache
 	redisCache, err := cache.NewRedisCache(
@@ -54,97 +87,150 @@ func main() {
 		redisPassword,
 	)
 	if err != nil {
-		log.Fatalf("Error connecting to Redis: %v", err)
+		return fmt.Errorf("error connecting to Redis: %w", err)
 	}
 
 	// Initialize repositories
 	postRepo := repository.NewPostgresPostRepository(db)
 	categoryRepo := repository.NewPostgresCategoryRepository(db)
+	revisionRepo := repository.NewPostgresRevisionRepository(db)
+	tagRepo := repository.NewPostgresTagRepository(db)
+	seriesRepo := repository.NewPostgresSeriesRepository(db)
 
 	// Initialize services with cache
-	postService := service.NewPostService(postRepo, categoryRepo, redisCache)
+	postService := service.NewPostService(postRepo, categoryRepo, revisionRepo, tagRepo, seriesRepo, redisCache, links.New(publicURL))
 	categoryService := service.NewCategoryService(categoryRepo, redisCache)
+	tagService := service.NewTagService(tagRepo, redisCache)
+	seriesService := service.NewSeriesService(seriesRepo, postRepo)
+
+	// Publish scheduled posts in the background
+	schedulerInterval, err := time.ParseDuration(getEnv("SCHEDULER_INTERVAL", "30s"))
+	if err != nil {
+		return fmt.Errorf("invalid SCHEDULER_INTERVAL: %w", err)
+	}
+	schedulerCtx, stopScheduler := context.WithCancel(context.Background())
+	schedulerDone := make(chan struct{})
+	go func() {
+		defer close(schedulerDone)
+		scheduler.New(postRepo, redisCache, schedulerInterval, logger).Run(schedulerCtx)
+	}()
+
+	// Readiness checks
+	checker := health.NewChecker(2 * time.Second)
+	checker.Add("postgres", db.PingContext)
+	checker.Add("redis", redisCache.Ping)
 
 	// Initialize handlers
 	postHandler := handler.NewPostHandler(postService)
 	categoryHandler := handler.NewCategoryHandler(categoryService)
+	tagHandler := handler.NewTagHandler(tagService)
+	seriesHandler := handler.NewSeriesHandler(seriesService)
 
 	// Initialize router
 	r := chi.NewRouter()
 
 	// Middleware
-	r.Use(middleware.Logger)
+	r.Use(logging.RequestID)
+	r.Use(logging.AccessLog(logger))
+	r.Use(tracing.RouteSpanName)
+	r.Use(metrics.Middleware)
 	r.Use(middleware.Recoverer)
 	r.Use(middleware.RealIP)
 	r.Use(middleware.Timeout(60 * time.Second))
+	r.Use(auth.Authenticate(jwtSecret))
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{"*"},
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
-		ExposedHeaders:   []string{"Link"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", logging.RequestIDHeader},
+		ExposedHeaders:   []string{"Link", "X-Total-Count", "X-Next-Cursor", logging.RequestIDHeader},
 		AllowCredentials: true,
 		MaxAge:           300,
 	}))
 
 	// Routes
+	r.Handle("/metrics", metrics.Handler())
+	r.Get("/healthz", health.Liveness)
+	r.Get("/readyz", checker.Readiness)
+	r.Get("/openapi.json", openapi.Handler(handler.OpenAPI()))
+
 	r.Route("/posts", func(r chi.Router) {
 		r.Get("/", postHandler.List)
 		r.Post("/", postHandler.Create)
+		r.Get("/scheduled", postHandler.ListScheduled)
+		r.Get("/feed", postHandler.SiteFeed)
 		r.Get("/{id}", postHandler.Get)
 		r.Put("/{id}", postHandler.Update)
 		r.Delete("/{id}", postHandler.Delete)
+		r.Get("/{id}/revisions", postHandler.ListRevisions)
+		r.Get("/{id}/revisions/diff", postHandler.DiffRevisions)
+		r.Get("/{id}/revisions/{revision}", postHandler.GetRevision)
+		r.Post("/{id}/revisions/{revision}/restore", postHandler.RestoreRevision)
+	})
+
+	r.Get("/sitemap.xml", postHandler.SitemapIndex)
+	r.Get("/sitemap-{name}.xml", postHandler.Sitemap)
+	r.Get("/authors/{id}/feed", postHandler.AuthorFeed)
+	r.Get("/tag-cloud", tagHandler.Cloud)
+
+	r.Route("/tags", func(r chi.Router) {
+		r.Get("/", tagHandler.List)
+		r.Get("/{tag}", tagHandler.Get)
+		r.Put("/{tag}", tagHandler.Update)
+		r.Get("/{tag}/feed", postHandler.TagFeed)
+		r.Post("/{tag}/aliases", tagHandler.AddAlias)
+		r.Delete("/{tag}/aliases/{alias}", tagHandler.RemoveAlias)
+		r.Post("/{tag}/merge", tagHandler.Merge)
+	})
+
+	r.Route("/series", func(r chi.Router) {
+		r.Get("/", seriesHandler.List)
+		r.Post("/", seriesHandler.Create)
+		r.Get("/{slug}", seriesHandler.Get)
+		r.Put("/{slug}", seriesHandler.Update)
+		r.Delete("/{slug}", seriesHandler.Delete)
+		r.Post("/{slug}/posts", seriesHandler.AddPost)
+		r.Put("/{slug}/posts", seriesHandler.Reorder)
+		r.Delete("/{slug}/posts/{post}", seriesHandler.RemovePost)
 	})
 
 	r.Route("/categories", func(r chi.Router) {
 		r.Get("/", categoryHandler.List)
 		r.Post("/", categoryHandler.Create)
 		r.Get("/{slug}", categoryHandler.Get)
+		r.Get("/{slug}/feed", postHandler.CategoryFeed)
 		r.Put("/{slug}", categoryHandler.Update)
 		r.Delete("/{slug}", categoryHandler.Delete)
+		r.Post("/{slug}/move", categoryHandler.Move)
 	})
 
 	// Start server
 	port := getEnv("PORT", "8081")
 	srv := &http.Server{
 		Addr:         ":" + port,
-		Handler:      r,
+		Handler:      otelhttp.NewHandler(r, "post-service"),
 		IdleTimeout:  time.Minute,
 		ReadTimeout:  10 * time.Second,
 		WriteTimeout: 30 * time.Second,
 	}
 
-	// Channel for server errors
-	serverErrors := make(chan error, 1)
-
-	// Start the server
-	go func() {
-		log.Printf("Post service starting on port %s", port)
-		serverErrors <- srv.ListenAndServe()
-	}()
-
-	// Channel for shutdown signals
-	shutdown := make(chan os.Signal, 1)
-	signal.Notify(shutdown, os.Interrupt, syscall.SIGTERM)
-
-	// Wait for shutdown or error
-	select {
-	case err := <-serverErrors:
-		log.Fatalf("Error starting server: %v", err)
-
-	case sig := <-shutdown:
-		log.Printf("Start shutdown: %v", sig)
-
-		// Give outstanding requests a deadline for completion
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
-
-		if err := srv.Shutdown(ctx); err != nil {
-			log.Printf("Graceful shutdown did not complete in %v: %v", 30*time.Second, err)
-			if err := srv.Close(); err != nil {
-				log.Fatalf("Could not stop http server: %v", err)
+	return health.Serve(srv, checker, logger,
+		// An interrupted publish batch rolls back and is retried on the next run
+		health.Closer{Name: "scheduler", Close: func(ctx context.Context) error {
+			stopScheduler()
+			select {
+			case <-schedulerDone:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
 			}
-		}
-	}
+		}},
+		// Let pending background writes finish before closing Redis and the
+		// database
+		health.Closer{Name: "post cache writes", Close: postService.Flush},
+		health.Closer{Name: "category cache writes", Close: categoryService.Flush},
+		health.Closer{Name: "tag cache writes", Close: tagService.Flush},
+		health.Closer{Name: "redis", Close: func(context.Context) error { return redisCache.Close() }},
+	)
 }
 
 func getEnv(key, fallback string) string {
@@ -6,23 +6,39 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/Thedrogon/blogbish/Internals/cachekeys"
+	"github.com/Thedrogon/blogbish/Internals/metrics"
 	"github.com/Thedrogon/blogbish/post-service/internal/models"
+	"github.com/redis/go-redis/extra/redisotel/v9"
 	"github.com/redis/go-redis/v9"
 )
 
 const (
-	postKeyPrefix     = "post:"
-	categoryKeyPrefix = "category:"
+	postKeyPrefix     = cachekeys.Post
+	categoryKeyPrefix = cachekeys.Category
+	feedKeyPrefix     = cachekeys.Feed
+	sitemapKeyPrefix  = cachekeys.Sitemap
 	defaultExpiration = 24 * time.Hour
+
+	// Feeds are dropped whenever a post changes; the expiry only bounds how
+	// long a missed invalidation can go unnoticed
+	feedExpiration = time.Hour
 )
 
 type Cache interface {
 	GetPost(ctx context.Context, slug string) (*models.Post, error)
 	SetPost(ctx context.Context, post *models.Post) error
 	DeletePost(ctx context.Context, slug string) error
+	DeletePosts(ctx context.Context) error
 	GetCategory(ctx context.Context, slug string) (*models.Category, error)
 	SetCategory(ctx context.Context, category *models.Category) error
 	DeleteCategory(ctx context.Context, slug string) error
+	GetFeed(ctx context.Context, key string) (*models.Document, error)
+	SetFeed(ctx context.Context, key string, feed *models.Document) error
+	DeleteFeeds(ctx context.Context) error
+	GetSitemap(ctx context.Context, name string) (*models.Document, error)
+	SetSitemap(ctx context.Context, name string, sitemap *models.Document) error
+	DeleteSitemaps(ctx context.Context, names ...string) error
 }
 
 type RedisCache struct {
@@ -36,6 +52,10 @@ func NewRedisCache(addr, password string) (*RedisCache, error) {
 		DB:       0,
 	})
 
+	if err := redisotel.InstrumentTracing(client); err != nil {
+		return nil, fmt.Errorf("failed to instrument Redis client: %w", err)
+	}
+
 	// Test connection
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -51,6 +71,7 @@ func (c *RedisCache) GetPost(ctx context.Context, slug string) (*models.Post, er
 	key := postKeyPrefix + slug
 	data, err := c.client.Get(ctx, key).Bytes()
 	if err == redis.Nil {
+		metrics.CacheMiss("post")
 		return nil, nil // Cache miss
 	}
 	if err != nil {
@@ -62,6 +83,7 @@ func (c *RedisCache) GetPost(ctx context.Context, slug string) (*models.Post, er
 		return nil, fmt.Errorf("failed to unmarshal post: %w", err)
 	}
 
+	metrics.CacheHit("post")
 	return &post, nil
 }
 
@@ -87,10 +109,17 @@ func (c *RedisCache) DeletePost(ctx context.Context, slug string) error {
 	return nil
 }
 
+// DeletePosts drops every cached post, for changes such as renaming a tag
+// that touch many posts at once
+func (c *RedisCache) DeletePosts(ctx context.Context) error {
+	return c.deleteAll(ctx, postKeyPrefix, "post")
+}
+
 func (c *RedisCache) GetCategory(ctx context.Context, slug string) (*models.Category, error) {
 	key := categoryKeyPrefix + slug
 	data, err := c.client.Get(ctx, key).Bytes()
 	if err == redis.Nil {
+		metrics.CacheMiss("category")
 		return nil, nil // Cache miss
 	}
 	if err != nil {
@@ -102,6 +131,7 @@ func (c *RedisCache) GetCategory(ctx context.Context, slug string) (*models.Cate
 		return nil, fmt.Errorf("failed to unmarshal category: %w", err)
 	}
 
+	metrics.CacheHit("category")
 	return &category, nil
 }
 
@@ -126,3 +156,110 @@ func (c *RedisCache) DeleteCategory(ctx context.Context, slug string) error {
 	}
 	return nil
 }
+
+func (c *RedisCache) GetFeed(ctx context.Context, key string) (*models.Document, error) {
+	data, err := c.client.Get(ctx, feedKeyPrefix+key).Bytes()
+	if err == redis.Nil {
+		metrics.CacheMiss("feed")
+		return nil, nil // Cache miss
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feed from cache: %w", err)
+	}
+
+	var feed models.Document
+	if err := json.Unmarshal(data, &feed); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal feed: %w", err)
+	}
+
+	metrics.CacheHit("feed")
+	return &feed, nil
+}
+
+func (c *RedisCache) SetFeed(ctx context.Context, key string, feed *models.Document) error {
+	data, err := json.Marshal(feed)
+	if err != nil {
+		return fmt.Errorf("failed to marshal feed: %w", err)
+	}
+
+	if err := c.client.Set(ctx, feedKeyPrefix+key, data, feedExpiration).Err(); err != nil {
+		return fmt.Errorf("failed to set feed in cache: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteFeeds drops every cached feed. Any post can appear in several feeds,
+// so they are invalidated together.
+func (c *RedisCache) DeleteFeeds(ctx context.Context) error {
+	return c.deleteAll(ctx, feedKeyPrefix, "feed")
+}
+
+// deleteAll unlinks every key starting with prefix
+func (c *RedisCache) deleteAll(ctx context.Context, prefix, kind string) error {
+	iter := c.client.Scan(ctx, 0, prefix+"*", 100).Iterator()
+	for iter.Next(ctx) {
+		if err := c.client.Unlink(ctx, iter.Val()).Err(); err != nil {
+			return fmt.Errorf("failed to delete %s from cache: %w", kind, err)
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return fmt.Errorf("failed to scan cached %ss: %w", kind, err)
+	}
+	return nil
+}
+
+func (c *RedisCache) GetSitemap(ctx context.Context, name string) (*models.Document, error) {
+	data, err := c.client.Get(ctx, sitemapKeyPrefix+name).Bytes()
+	if err == redis.Nil {
+		metrics.CacheMiss("sitemap")
+		return nil, nil // Cache miss
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sitemap from cache: %w", err)
+	}
+
+	var sitemap models.Document
+	if err := json.Unmarshal(data, &sitemap); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal sitemap: %w", err)
+	}
+
+	metrics.CacheHit("sitemap")
+	return &sitemap, nil
+}
+
+func (c *RedisCache) SetSitemap(ctx context.Context, name string, sitemap *models.Document) error {
+	data, err := json.Marshal(sitemap)
+	if err != nil {
+		return fmt.Errorf("failed to marshal sitemap: %w", err)
+	}
+
+	if err := c.client.Set(ctx, sitemapKeyPrefix+name, data, defaultExpiration).Err(); err != nil {
+		return fmt.Errorf("failed to set sitemap in cache: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteSitemaps drops the named sitemaps, so only those affected by a
+// change are regenerated
+func (c *RedisCache) DeleteSitemaps(ctx context.Context, names ...string) error {
+	keys := make([]string, len(names))
+	for i, name := range names {
+		keys[i] = sitemapKeyPrefix + name
+	}
+	if err := c.client.Del(ctx, keys...).Err(); err != nil {
+		return fmt.Errorf("failed to delete sitemaps from cache: %w", err)
+	}
+	return nil
+}
+
+// Ping checks that Redis is reachable
+func (c *RedisCache) Ping(ctx context.Context) error {
+	return c.client.Ping(ctx).Err()
+}
+
+// Close closes the Redis client
+func (c *RedisCache) Close() error {
+	return c.client.Close()
+}
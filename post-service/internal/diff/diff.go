@@ -0,0 +1,246 @@
+// Package diff produces line-based unified diffs
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Context is the number of unchanged lines shown around each change
+const Context = 3
+
+// maxEdits bounds the Myers search. Inputs that differ by more lines than
+// this are diffed as one block replacement, which keeps memory use flat.
+const maxEdits = 2048
+
+type op byte
+
+const (
+	equal  op = ' '
+	remove op = '-'
+	insert op = '+'
+)
+
+type edit struct {
+	op   op
+	line string
+	a, b int // zero-based line numbers before the edit
+}
+
+// Unified returns a unified diff turning a into b, with fromName and toName
+// in the file headers. Identical inputs produce an empty string.
+func Unified(fromName, toName, a, b string) string {
+	edits := compute(lines(a), lines(b))
+
+	var out strings.Builder
+	for _, h := range hunks(edits) {
+		if out.Len() == 0 {
+			fmt.Fprintf(&out, "--- %s\n+++ %s\n", fromName, toName)
+		}
+		writeHunk(&out, edits[h[0]:h[1]])
+	}
+	return out.String()
+}
+
+func lines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// compute returns a shortest edit script using Myers' algorithm, after
+// trimming the common prefix and suffix
+func compute(a, b []string) []edit {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	var edits []edit
+	for i := 0; i < prefix; i++ {
+		edits = append(edits, edit{equal, a[i], i, i})
+	}
+
+	midA, midB := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	for _, e := range myers(midA, midB) {
+		e.a += prefix
+		e.b += prefix
+		edits = append(edits, e)
+	}
+
+	for i := 0; i < suffix; i++ {
+		ai, bi := len(a)-suffix+i, len(b)-suffix+i
+		edits = append(edits, edit{equal, a[ai], ai, bi})
+	}
+	return edits
+}
+
+func myers(a, b []string) []edit {
+	n, m := len(a), len(b)
+	if n == 0 && m == 0 {
+		return nil
+	}
+
+	limit := n + m
+	if limit > maxEdits {
+		limit = maxEdits
+	}
+
+	// trace[d][k+d] is the furthest x reached on diagonal k after d edits
+	offset := limit + 1
+	v := make([]int, 2*limit+3)
+	var trace [][]int
+
+	for d := 0; d <= limit; d++ {
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+
+			if x >= n && y >= m {
+				trace = append(trace, append([]int(nil), v[offset-d:offset+d+1]...))
+				return backtrack(a, b, trace)
+			}
+		}
+		trace = append(trace, append([]int(nil), v[offset-d:offset+d+1]...))
+	}
+
+	return replace(a, b)
+}
+
+// backtrack walks the trace from the end to recover the edit script
+func backtrack(a, b []string, trace [][]int) []edit {
+	x, y := len(a), len(b)
+	var reversed []edit
+
+	for d := len(trace) - 1; d > 0; d-- {
+		prev := trace[d-1]
+		at := func(k int) int { return prev[k+d-1] }
+
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && at(k-1) < at(k+1)) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := at(prevK)
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			x--
+			y--
+			reversed = append(reversed, edit{equal, a[x], x, y})
+		}
+		if x == prevX {
+			y--
+			reversed = append(reversed, edit{insert, b[y], x, y})
+		} else {
+			x--
+			reversed = append(reversed, edit{remove, a[x], x, y})
+		}
+	}
+	for x > 0 && y > 0 {
+		x--
+		y--
+		reversed = append(reversed, edit{equal, a[x], x, y})
+	}
+
+	edits := make([]edit, len(reversed))
+	for i, e := range reversed {
+		edits[len(reversed)-1-i] = e
+	}
+	return edits
+}
+
+func replace(a, b []string) []edit {
+	edits := make([]edit, 0, len(a)+len(b))
+	for i, line := range a {
+		edits = append(edits, edit{remove, line, i, 0})
+	}
+	for i, line := range b {
+		edits = append(edits, edit{insert, line, len(a), i})
+	}
+	return edits
+}
+
+// hunks returns [start, end) ranges of edits, each holding one or more
+// changes with up to Context unchanged lines around them
+func hunks(edits []edit) [][2]int {
+	var ranges [][2]int
+	for i := 0; i < len(edits); i++ {
+		if edits[i].op == equal {
+			continue
+		}
+
+		start := i - Context
+		if start < 0 {
+			start = 0
+		}
+		if n := len(ranges); n > 0 && start <= ranges[n-1][1] {
+			start = ranges[n-1][0]
+			ranges = ranges[:n-1]
+		}
+
+		// Extend over changes separated by at most 2*Context equal lines
+		end := i + 1
+		for j := end; j < len(edits) && j < end+2*Context+1; j++ {
+			if edits[j].op != equal {
+				end = j + 1
+			}
+		}
+		i = end - 1
+
+		end += Context
+		if end > len(edits) {
+			end = len(edits)
+		}
+		ranges = append(ranges, [2]int{start, end})
+	}
+	return ranges
+}
+
+func writeHunk(out *strings.Builder, edits []edit) {
+	var aCount, bCount int
+	for _, e := range edits {
+		if e.op != insert {
+			aCount++
+		}
+		if e.op != remove {
+			bCount++
+		}
+	}
+
+	fmt.Fprintf(out, "@@ -%s +%s @@\n", span(edits[0].a, aCount), span(edits[0].b, bCount))
+	for _, e := range edits {
+		out.WriteByte(byte(e.op))
+		out.WriteString(e.line)
+		out.WriteByte('\n')
+	}
+}
+
+// span formats a hunk range. Empty ranges name the line before them, as
+// diff(1) does.
+func span(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
@@ -0,0 +1,182 @@
+package diff
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestUnified(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want string
+	}{
+		{"identical", "a\nb\n", "a\nb\n", ""},
+		{"both empty", "", "", ""},
+		{
+			name: "change in the middle",
+			a:    "1\n2\n3\n4\n5\n6\n7\n8\n9\n",
+			b:    "1\n2\n3\n4\nfive\n6\n7\n8\n9\n",
+			want: "--- a\n+++ b\n@@ -2,7 +2,7 @@\n 2\n 3\n 4\n-5\n+five\n 6\n 7\n 8\n",
+		},
+		{
+			name: "from empty",
+			a:    "",
+			b:    "x\ny\n",
+			want: "--- a\n+++ b\n@@ -0,0 +1,2 @@\n+x\n+y\n",
+		},
+		{
+			name: "to empty",
+			a:    "x\n",
+			b:    "",
+			want: "--- a\n+++ b\n@@ -1 +0,0 @@\n-x\n",
+		},
+		{
+			name: "separate hunks",
+			a:    "a\n1\n2\n3\n4\n5\n6\n7\n8\nb\n",
+			b:    "A\n1\n2\n3\n4\n5\n6\n7\n8\nB\n",
+			want: "--- a\n+++ b\n@@ -1,4 +1,4 @@\n-a\n+A\n 1\n 2\n 3\n@@ -7,4 +7,4 @@\n 6\n 7\n 8\n-b\n+B\n",
+		},
+		{
+			name: "nearby changes share a hunk",
+			a:    "a\n1\n2\n3\n4\n5\n6\nb\n",
+			b:    "A\n1\n2\n3\n4\n5\n6\nB\n",
+			want: "--- a\n+++ b\n@@ -1,8 +1,8 @@\n-a\n+A\n 1\n 2\n 3\n 4\n 5\n 6\n-b\n+B\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Unified("a", "b", tt.a, tt.b); got != tt.want {
+				t.Errorf("Unified() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnifiedRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	random := func(n int) []string {
+		lines := make([]string, n)
+		for i := range lines {
+			lines[i] = string(rune('a' + rng.Intn(4)))
+		}
+		return lines
+	}
+
+	for i := 0; i < 500; i++ {
+		a, b := random(rng.Intn(30)), random(rng.Intn(30))
+		patch := Unified("a", "b", join(a), join(b))
+
+		got, err := apply(a, patch)
+		if err != nil {
+			t.Fatalf("apply(%q, %q): %v", a, patch, err)
+		}
+		if !slices.Equal(got, b) {
+			t.Fatalf("patch %q turns %q into %q, want %q", patch, a, got, b)
+		}
+
+		// Myers finds a shortest edit script
+		if changes, want := countChanges(patch), len(a)+len(b)-2*lcs(a, b); changes != want {
+			t.Fatalf("patch %q turning %q into %q has %d changes, want %d", patch, a, b, changes, want)
+		}
+	}
+}
+
+func TestUnifiedBeyondMaxEdits(t *testing.T) {
+	var a, b []string
+	for i := 0; i < maxEdits; i++ {
+		a = append(a, "a"+strconv.Itoa(i))
+		b = append(b, "b"+strconv.Itoa(i))
+	}
+
+	patch := Unified("a", "b", join(a), join(b))
+	got, err := apply(a, patch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(got, b) {
+		t.Fatal("block replacement does not round trip")
+	}
+}
+
+func join(lines []string) string {
+	if len(lines) == 0 {
+		return ""
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// apply applies a unified diff produced by Unified to a
+func apply(a []string, patch string) ([]string, error) {
+	var out []string
+	pos := 0
+
+	for _, line := range strings.Split(strings.TrimSuffix(patch, "\n"), "\n") {
+		switch {
+		case line == "" || strings.HasPrefix(line, "--- ") || strings.HasPrefix(line, "+++ "):
+		case strings.HasPrefix(line, "@@ "):
+			var start, count int
+			from := strings.Fields(line)[1][1:]
+			startText, countText, ok := strings.Cut(from, ",")
+			start, _ = strconv.Atoi(startText)
+			count = 1
+			if ok {
+				count, _ = strconv.Atoi(countText)
+			}
+			if count > 0 {
+				start--
+			}
+			if start < pos || start > len(a) {
+				return nil, fmt.Errorf("hunk %q out of order", line)
+			}
+			out = append(out, a[pos:start]...)
+			pos = start
+		case line[0] == ' ' || line[0] == '-':
+			if pos >= len(a) || a[pos] != line[1:] {
+				return nil, fmt.Errorf("line %d does not match %q", pos+1, line)
+			}
+			if line[0] == ' ' {
+				out = append(out, a[pos])
+			}
+			pos++
+		case line[0] == '+':
+			out = append(out, line[1:])
+		default:
+			return nil, fmt.Errorf("unexpected line %q", line)
+		}
+	}
+	return append(out, a[pos:]...), nil
+}
+
+func countChanges(patch string) int {
+	n := 0
+	for _, line := range strings.Split(patch, "\n") {
+		if (strings.HasPrefix(line, "-") && !strings.HasPrefix(line, "--- ")) ||
+			(strings.HasPrefix(line, "+") && !strings.HasPrefix(line, "+++ ")) {
+			n++
+		}
+	}
+	return n
+}
+
+// lcs returns the length of the longest common subsequence of a and b
+func lcs(a, b []string) int {
+	prev := make([]int, len(b)+1)
+	for i := range a {
+		cur := make([]int, len(b)+1)
+		for j := range b {
+			if a[i] == b[j] {
+				cur[j+1] = prev[j] + 1
+			} else {
+				cur[j+1] = max(prev[j+1], cur[j])
+			}
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
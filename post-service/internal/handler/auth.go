@@ -0,0 +1,17 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Thedrogon/blogbish/Internals/auth"
+	"github.com/Thedrogon/blogbish/Internals/problem"
+)
+
+// caller returns the authenticated user, answering 401 when there is none
+func caller(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	userID, ok := auth.UserID(r.Context())
+	if !ok {
+		problem.Error(w, r, http.StatusUnauthorized, problem.CodeUnauthorized, "authentication is required")
+	}
+	return userID, ok
+}
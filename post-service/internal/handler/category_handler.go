@@ -3,17 +3,20 @@ package handler
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 
+	"github.com/Thedrogon/blogbish/Internals/problem"
+	"github.com/Thedrogon/blogbish/Internals/validation/rules"
 	"github.com/Thedrogon/blogbish/post-service/internal/models"
 	"github.com/Thedrogon/blogbish/post-service/internal/service"
 	"github.com/go-chi/chi/v5"
 )
 
 type CategoryHandler struct {
-	categoryService service.CategoryService
+	categoryService *service.CategoryService
 }
 
-func NewCategoryHandler(categoryService service.CategoryService) *CategoryHandler {
+func NewCategoryHandler(categoryService *service.CategoryService) *CategoryHandler {
 	return &CategoryHandler{
 		categoryService: categoryService,
 	}
@@ -22,13 +25,17 @@ func NewCategoryHandler(categoryService service.CategoryService) *CategoryHandle
 func (h *CategoryHandler) Create(w http.ResponseWriter, r *http.Request) {
 	var input models.CategoryCreate
 	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		problem.Error(w, r, http.StatusBadRequest, problem.CodeInvalidBody, "request body must be valid JSON")
+		return
+	}
+	if fields := rules.Struct(&input); fields != nil {
+		problem.Invalid(w, r, fields)
 		return
 	}
 
 	category, err := h.categoryService.CreateCategory(r.Context(), &input)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problem.WriteError(w, r, problems, err)
 		return
 	}
 
@@ -40,17 +47,18 @@ func (h *CategoryHandler) Create(w http.ResponseWriter, r *http.Request) {
 func (h *CategoryHandler) Get(w http.ResponseWriter, r *http.Request) {
 	slug := chi.URLParam(r, "slug")
 	if slug == "" {
-		http.Error(w, "Missing category slug", http.StatusBadRequest)
+		problem.Error(w, r, http.StatusBadRequest, problem.CodeInvalidInput, "category slug is required")
 		return
 	}
 
 	category, err := h.categoryService.GetCategory(r.Context(), slug)
 	if err != nil {
-		if err == service.ErrNotFound {
-			http.Error(w, "Category not found", http.StatusNotFound)
-			return
-		}
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problem.WriteError(w, r, problems, err)
+		return
+	}
+
+	if category.Slug != slug {
+		redirectToSlug(w, r, category.Slug)
 		return
 	}
 
@@ -61,23 +69,23 @@ func (h *CategoryHandler) Get(w http.ResponseWriter, r *http.Request) {
 func (h *CategoryHandler) Update(w http.ResponseWriter, r *http.Request) {
 	slug := chi.URLParam(r, "slug")
 	if slug == "" {
-		http.Error(w, "Missing category slug", http.StatusBadRequest)
+		problem.Error(w, r, http.StatusBadRequest, problem.CodeInvalidInput, "category slug is required")
 		return
 	}
 
 	var input models.CategoryUpdate
 	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		problem.Error(w, r, http.StatusBadRequest, problem.CodeInvalidBody, "request body must be valid JSON")
+		return
+	}
+	if fields := rules.Struct(&input); fields != nil {
+		problem.Invalid(w, r, fields)
 		return
 	}
 
 	category, err := h.categoryService.UpdateCategory(r.Context(), slug, &input)
 	if err != nil {
-		if err == service.ErrNotFound {
-			http.Error(w, "Category not found", http.StatusNotFound)
-			return
-		}
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problem.WriteError(w, r, problems, err)
 		return
 	}
 
@@ -88,26 +96,47 @@ func (h *CategoryHandler) Update(w http.ResponseWriter, r *http.Request) {
 func (h *CategoryHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	slug := chi.URLParam(r, "slug")
 	if slug == "" {
-		http.Error(w, "Missing category slug", http.StatusBadRequest)
+		problem.Error(w, r, http.StatusBadRequest, problem.CodeInvalidInput, "category slug is required")
 		return
 	}
 
 	if err := h.categoryService.DeleteCategory(r.Context(), slug); err != nil {
-		if err == service.ErrNotFound {
-			http.Error(w, "Category not found", http.StatusNotFound)
-			return
-		}
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problem.WriteError(w, r, problems, err)
 		return
 	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
 
+func (h *CategoryHandler) Move(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+	if slug == "" {
+		problem.Error(w, r, http.StatusBadRequest, problem.CodeInvalidInput, "category slug is required")
+		return
+	}
+
+	var input models.CategoryMove
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		problem.Error(w, r, http.StatusBadRequest, problem.CodeInvalidBody, "request body must be valid JSON")
+		return
+	}
+
+	category, err := h.categoryService.MoveCategory(r.Context(), slug, &input)
+	if err != nil {
+		problem.WriteError(w, r, problems, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(category)
+}
+
 func (h *CategoryHandler) List(w http.ResponseWriter, r *http.Request) {
-	categories, err := h.categoryService.ListCategories(r.Context())
+	tree, _ := strconv.ParseBool(r.URL.Query().Get("tree"))
+
+	categories, err := h.categoryService.ListCategories(r.Context(), tree)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problem.WriteError(w, r, problems, err)
 		return
 	}
 
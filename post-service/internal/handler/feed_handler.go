@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Thedrogon/blogbish/Internals/problem"
+	"github.com/Thedrogon/blogbish/post-service/internal/feed"
+	"github.com/Thedrogon/blogbish/post-service/internal/models"
+	"github.com/Thedrogon/blogbish/post-service/internal/service"
+	"github.com/go-chi/chi/v5"
+)
+
+func (h *PostHandler) SiteFeed(w http.ResponseWriter, r *http.Request) {
+	h.serveFeed(w, r, service.FeedScope{})
+}
+
+func (h *PostHandler) CategoryFeed(w http.ResponseWriter, r *http.Request) {
+	h.serveFeed(w, r, service.FeedScope{Category: chi.URLParam(r, "slug")})
+}
+
+func (h *PostHandler) TagFeed(w http.ResponseWriter, r *http.Request) {
+	h.serveFeed(w, r, service.FeedScope{Tag: chi.URLParam(r, "tag")})
+}
+
+func (h *PostHandler) AuthorFeed(w http.ResponseWriter, r *http.Request) {
+	authorID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || authorID < 1 {
+		problem.Error(w, r, http.StatusBadRequest, problem.CodeInvalidInput, "author ID must be a positive integer")
+		return
+	}
+	h.serveFeed(w, r, service.FeedScope{AuthorID: authorID})
+}
+
+// serveFeed writes the feed in the format named by ?format, or else the one
+// the Accept header prefers, defaulting to RSS
+func (h *PostHandler) serveFeed(w http.ResponseWriter, r *http.Request, scope service.FeedScope) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = feedFormat(r.Header.Get("Accept"))
+	}
+	if _, ok := feed.ContentTypes[format]; !ok {
+		problem.Error(w, r, http.StatusBadRequest, problem.CodeInvalidInput, "format must be one of: rss, atom, json")
+		return
+	}
+
+	doc, err := h.postService.Feed(r.Context(), scope, format)
+	if err != nil {
+		problem.WriteError(w, r, problems, err)
+		return
+	}
+
+	w.Header().Add("Vary", "Accept")
+	serveDocument(w, r, doc)
+}
+
+// serveDocument writes a feed or sitemap. Conditional requests are answered
+// with 304 Not Modified by http.ServeContent.
+func serveDocument(w http.ResponseWriter, r *http.Request, doc *models.Document) {
+	w.Header().Set("Content-Type", doc.ContentType)
+	w.Header().Set("ETag", doc.ETag)
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	http.ServeContent(w, r, "", doc.LastModified, bytes.NewReader(doc.Body))
+}
+
+func feedFormat(accept string) string {
+	switch {
+	case strings.Contains(accept, "application/atom+xml"):
+		return feed.Atom
+	case strings.Contains(accept, "application/feed+json"):
+		return feed.JSON
+	default:
+		return feed.RSS
+	}
+}
@@ -0,0 +1,527 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Thedrogon/blogbish/Internals/openapi"
+	"github.com/Thedrogon/blogbish/Internals/problem"
+	"github.com/Thedrogon/blogbish/post-service/internal/models"
+)
+
+// OpenAPI describes the post service API
+func OpenAPI() *openapi.Document {
+	b := openapi.NewBuilder("Post Service", "1.0.0", "Blog posts, categories, tags and series.")
+	fail := func(description string) openapi.Response { return b.Fails(description, problem.Problem{}) }
+
+	postID := openapi.PathParam("id", "string", "Post slug")
+	slug := openapi.PathParam("slug", "string", "Category slug")
+	tag := openapi.PathParam("tag", "string", "Tag slug, alias or name")
+
+	// Feeds are served as RSS, Atom or JSON Feed documents
+	feedFormat := openapi.QueryParam("format", "string", "rss (default), atom or json; the Accept header is used when omitted")
+	feed := openapi.Response{
+		Description: "Feed of the latest published posts",
+		Content: map[string]openapi.MediaType{
+			"application/rss+xml":   {Schema: &openapi.Schema{Type: "string"}},
+			"application/atom+xml":  {Schema: &openapi.Schema{Type: "string"}},
+			"application/feed+json": {Schema: &openapi.Schema{Type: "object"}},
+		},
+	}
+	feedResponses := func(notFound string) map[string]openapi.Response {
+		responses := map[string]openapi.Response{
+			"200": feed,
+			"304": openapi.Returns("Not modified since If-None-Match or If-Modified-Since", nil),
+			"400": fail("Unknown format"),
+		}
+		if notFound != "" {
+			responses["404"] = fail(notFound)
+		}
+		return responses
+	}
+
+	b.Add(http.MethodGet, "/posts", openapi.Operation{
+		OperationID: "listPosts",
+		Summary:     "List posts",
+		Tags:        []string{"posts"},
+		Parameters: []openapi.Parameter{
+			openapi.QueryParam("cursor", "string", "Cursor of the page to fetch, from X-Next-Cursor or the next link"),
+			openapi.QueryParam("page", "integer", "Page number, starting at 1; ignored with a cursor"),
+			openapi.QueryParam("page_size", "integer", "Posts per page, at most 100"),
+			openapi.QueryParam("category", "string", "Category slugs, comma-separated or repeated; subcategories are included"),
+			openapi.QueryParam("author", "string", "Author IDs, comma-separated or repeated"),
+			openapi.QueryParam("status", "string", "Post status"),
+			openapi.QueryParam("tag", "string", "Tags, comma-separated or repeated"),
+			openapi.QueryParam("tag_match", "string", "any (default) to match posts with any of the tags, all for posts with every tag"),
+			openapi.QueryParam("published_after", "string", "Only posts published at or after this RFC 3339 timestamp or date"),
+			openapi.QueryParam("published_before", "string", "Only posts published before this RFC 3339 timestamp or date"),
+			openapi.QueryParam("exclude", "string", "Post IDs to leave out, comma-separated or repeated"),
+			openapi.QueryParam("q", "string", "Full-text search in web search syntax: \"quoted phrases\", or, -excluded"),
+			openapi.QueryParam("sort", "string", "published_at (default), view_count, title, updated_at, or relevance (the default with q)"),
+			openapi.QueryParam("order", "string", "asc or desc; defaults to asc for title and desc otherwise"),
+		},
+		Responses: map[string]openapi.Response{
+			"200": openapi.Paged(openapi.Returns("Posts", b.ArrayOf(models.PostResponse{}))),
+			"400": fail("Invalid query parameter or cursor"),
+			"422": fail("Category does not exist"),
+		},
+	})
+
+	b.Add(http.MethodPost, "/posts", openapi.Operation{
+		OperationID: "createPost",
+		Summary:     "Create a post",
+		Tags:        []string{"posts"},
+		Security:    openapi.BearerAuth,
+		RequestBody: openapi.JSON(b.Ref(models.PostCreate{})),
+		Responses: map[string]openapi.Response{
+			"201": openapi.Returns("Post created", b.Ref(models.PostResponse{})),
+			"400": fail("Malformed request body"),
+			"422": fail("Validation failed, category does not exist or publish_at is not in the future"),
+		},
+	})
+
+	b.Add(http.MethodGet, "/posts/scheduled", openapi.Operation{
+		OperationID: "listScheduledPosts",
+		Summary:     "List upcoming scheduled posts, soonest first",
+		Tags:        []string{"posts"},
+		Parameters: []openapi.Parameter{
+			openapi.QueryParam("limit", "integer", "Maximum number of posts, at most 100"),
+		},
+		Responses: map[string]openapi.Response{
+			"200": openapi.Returns("Scheduled posts", b.ArrayOf(models.PostResponse{})),
+		},
+	})
+
+	b.Add(http.MethodGet, "/posts/feed", openapi.Operation{
+		OperationID: "getSiteFeed",
+		Summary:     "Feed of the whole site",
+		Tags:        []string{"feeds"},
+		Parameters:  []openapi.Parameter{feedFormat},
+		Responses:   feedResponses(""),
+	})
+
+	b.Add(http.MethodGet, "/categories/{slug}/feed", openapi.Operation{
+		OperationID: "getCategoryFeed",
+		Summary:     "Feed of a category",
+		Tags:        []string{"feeds"},
+		Parameters:  []openapi.Parameter{slug, feedFormat},
+		Responses:   feedResponses("Category not found"),
+	})
+
+	b.Add(http.MethodGet, "/tags/{tag}/feed", openapi.Operation{
+		OperationID: "getTagFeed",
+		Summary:     "Feed of a tag",
+		Tags:        []string{"feeds"},
+		Parameters:  []openapi.Parameter{tag, feedFormat},
+		Responses:   feedResponses("Tag not found"),
+	})
+
+	b.Add(http.MethodGet, "/authors/{id}/feed", openapi.Operation{
+		OperationID: "getAuthorFeed",
+		Summary:     "Feed of an author",
+		Tags:        []string{"feeds"},
+		Parameters:  []openapi.Parameter{openapi.PathParam("id", "integer", "Author ID"), feedFormat},
+		Responses:   feedResponses(""),
+	})
+
+	sitemapXML := openapi.Response{
+		Description: "Sitemap",
+		Content:     map[string]openapi.MediaType{"application/xml": {Schema: &openapi.Schema{Type: "string"}}},
+	}
+
+	b.Add(http.MethodGet, "/sitemap.xml", openapi.Operation{
+		OperationID: "getSitemapIndex",
+		Summary:     "Sitemap index listing the sitemaps of published posts and categories",
+		Tags:        []string{"sitemaps"},
+		Responses: map[string]openapi.Response{
+			"200": sitemapXML,
+			"304": openapi.Returns("Not modified since If-None-Match or If-Modified-Since", nil),
+		},
+	})
+
+	b.Add(http.MethodGet, "/sitemap-{name}.xml", openapi.Operation{
+		OperationID: "getSitemap",
+		Summary:     "Sitemap of up to 50,000 posts or categories, e.g. posts-1",
+		Tags:        []string{"sitemaps"},
+		Parameters:  []openapi.Parameter{openapi.PathParam("name", "string", "Sitemap name from the index")},
+		Responses: map[string]openapi.Response{
+			"200": sitemapXML,
+			"304": openapi.Returns("Not modified since If-None-Match or If-Modified-Since", nil),
+			"404": fail("No such sitemap"),
+		},
+	})
+
+	b.Add(http.MethodGet, "/posts/{id}", openapi.Operation{
+		OperationID: "getPost",
+		Summary:     "Get a post, with its SEO metadata and place in a series",
+		Tags:        []string{"posts"},
+		Parameters:  []openapi.Parameter{postID},
+		Responses: map[string]openapi.Response{
+			"200": openapi.Returns("Post", b.Ref(models.PostResponse{})),
+			"301": openapi.Returns("Post renamed; Location holds its current slug", nil),
+			"404": fail("Post not found"),
+		},
+	})
+
+	b.Add(http.MethodPut, "/posts/{id}", openapi.Operation{
+		OperationID: "updatePost",
+		Summary:     "Update a post",
+		Tags:        []string{"posts"},
+		Security:    openapi.BearerAuth,
+		Parameters:  []openapi.Parameter{postID},
+		RequestBody: openapi.JSON(b.Ref(models.PostUpdate{})),
+		Responses: map[string]openapi.Response{
+			"200": openapi.Returns("Post updated", b.Ref(models.PostResponse{})),
+			"403": fail("Not the author of the post"),
+			"404": fail("Post not found"),
+			"409": fail("Published posts can't be scheduled"),
+			"422": fail("Validation failed or publish_at is not in the future"),
+		},
+	})
+
+	b.Add(http.MethodDelete, "/posts/{id}", openapi.Operation{
+		OperationID: "deletePost",
+		Summary:     "Delete a post",
+		Tags:        []string{"posts"},
+		Security:    openapi.BearerAuth,
+		Parameters:  []openapi.Parameter{postID},
+		Responses: map[string]openapi.Response{
+			"204": openapi.Returns("Post deleted", nil),
+			"403": fail("Not the author of the post"),
+			"404": fail("Post not found"),
+		},
+	})
+
+	revision := openapi.PathParam("revision", "integer", "Revision number, starting at 1")
+
+	b.Add(http.MethodGet, "/posts/{id}/revisions", openapi.Operation{
+		OperationID: "listPostRevisions",
+		Summary:     "List the revisions of a post, newest first",
+		Tags:        []string{"revisions"},
+		Parameters:  []openapi.Parameter{postID},
+		Responses: map[string]openapi.Response{
+			"200": openapi.Returns("Revisions", b.ArrayOf(models.PostRevisionResponse{})),
+			"404": fail("Post not found"),
+		},
+	})
+
+	b.Add(http.MethodGet, "/posts/{id}/revisions/diff", openapi.Operation{
+		OperationID: "diffPostRevisions",
+		Summary:     "Unified diff between two revisions; send Accept: text/x-diff for the bare patch",
+		Tags:        []string{"revisions"},
+		Parameters: []openapi.Parameter{
+			postID,
+			openapi.QueryParam("from", "integer", "Older revision"),
+			openapi.QueryParam("to", "integer", "Newer revision, defaults to the latest"),
+		},
+		Responses: map[string]openapi.Response{
+			"200": openapi.Returns("Diff", b.Ref(models.RevisionDiff{})),
+			"400": fail("Invalid revision numbers"),
+			"404": fail("Post or revision not found"),
+		},
+	})
+
+	b.Add(http.MethodGet, "/posts/{id}/revisions/{revision}", openapi.Operation{
+		OperationID: "getPostRevision",
+		Summary:     "Get a revision of a post",
+		Tags:        []string{"revisions"},
+		Parameters:  []openapi.Parameter{postID, revision},
+		Responses: map[string]openapi.Response{
+			"200": openapi.Returns("Revision", b.Ref(models.PostRevisionResponse{})),
+			"404": fail("Post or revision not found"),
+		},
+	})
+
+	b.Add(http.MethodPost, "/posts/{id}/revisions/{revision}/restore", openapi.Operation{
+		OperationID: "restorePostRevision",
+		Summary:     "Restore a revision as the newest revision",
+		Tags:        []string{"revisions"},
+		Security:    openapi.BearerAuth,
+		Parameters:  []openapi.Parameter{postID, revision},
+		Responses: map[string]openapi.Response{
+			"200": openapi.Returns("Post restored", b.Ref(models.PostResponse{})),
+			"403": fail("Not the author of the post"),
+			"404": fail("Post or revision not found"),
+			"422": fail("The revision's category no longer exists"),
+		},
+	})
+
+	b.Add(http.MethodGet, "/categories", openapi.Operation{
+		OperationID: "listCategories",
+		Summary:     "List categories by name, or as a tree of root categories with their children",
+		Tags:        []string{"categories"},
+		Parameters:  []openapi.Parameter{openapi.QueryParam("tree", "boolean", "Nest categories under their parents")},
+		Responses: map[string]openapi.Response{
+			"200": openapi.Returns("Categories", b.ArrayOf(models.CategoryResponse{})),
+		},
+	})
+
+	b.Add(http.MethodPost, "/categories", openapi.Operation{
+		OperationID: "createCategory",
+		Summary:     "Create a category",
+		Tags:        []string{"categories"},
+		Security:    openapi.BearerAuth,
+		RequestBody: openapi.JSON(b.Ref(models.CategoryCreate{})),
+		Responses: map[string]openapi.Response{
+			"201": openapi.Returns("Category created", b.Ref(models.CategoryResponse{})),
+			"400": fail("Malformed request body"),
+			"422": fail("Validation failed or parent category does not exist"),
+		},
+	})
+
+	b.Add(http.MethodGet, "/categories/{slug}", openapi.Operation{
+		OperationID: "getCategory",
+		Summary:     "Get a category with its breadcrumb path",
+		Tags:        []string{"categories"},
+		Parameters:  []openapi.Parameter{slug},
+		Responses: map[string]openapi.Response{
+			"200": openapi.Returns("Category", b.Ref(models.CategoryResponse{})),
+			"301": openapi.Returns("Category renamed; Location holds its current slug", nil),
+			"404": fail("Category not found"),
+		},
+	})
+
+	b.Add(http.MethodPut, "/categories/{slug}", openapi.Operation{
+		OperationID: "updateCategory",
+		Summary:     "Update a category",
+		Tags:        []string{"categories"},
+		Security:    openapi.BearerAuth,
+		Parameters:  []openapi.Parameter{slug},
+		RequestBody: openapi.JSON(b.Ref(models.CategoryUpdate{})),
+		Responses: map[string]openapi.Response{
+			"200": openapi.Returns("Category updated", b.Ref(models.CategoryResponse{})),
+			"404": fail("Category not found"),
+			"422": fail("Validation failed"),
+		},
+	})
+
+	b.Add(http.MethodDelete, "/categories/{slug}", openapi.Operation{
+		OperationID: "deleteCategory",
+		Summary:     "Delete a category",
+		Tags:        []string{"categories"},
+		Security:    openapi.BearerAuth,
+		Parameters:  []openapi.Parameter{slug},
+		Responses: map[string]openapi.Response{
+			"204": openapi.Returns("Category deleted", nil),
+			"404": fail("Category not found"),
+			"409": fail("Category still has posts or subcategories"),
+		},
+	})
+
+	b.Add(http.MethodPost, "/categories/{slug}/move", openapi.Operation{
+		OperationID: "moveCategory",
+		Summary:     "Move a category and its subcategories under another parent, or to the root",
+		Tags:        []string{"categories"},
+		Security:    openapi.BearerAuth,
+		Parameters:  []openapi.Parameter{slug},
+		RequestBody: openapi.JSON(b.Ref(models.CategoryMove{})),
+		Responses: map[string]openapi.Response{
+			"200": openapi.Returns("Category moved", b.Ref(models.CategoryResponse{})),
+			"400": fail("Malformed request body"),
+			"404": fail("Category not found"),
+			"409": fail("Parent is the category or one of its subcategories"),
+			"422": fail("Parent category does not exist"),
+		},
+	})
+
+	b.Add(http.MethodGet, "/tags", openapi.Operation{
+		OperationID: "listTags",
+		Summary:     "List tags by name, with their number of published posts",
+		Tags:        []string{"tags"},
+		Responses: map[string]openapi.Response{
+			"200": openapi.Returns("Tags", b.ArrayOf(models.TagResponse{})),
+		},
+	})
+
+	b.Add(http.MethodGet, "/tag-cloud", openapi.Operation{
+		OperationID: "getTagCloud",
+		Summary:     "Most used tags, alphabetically, weighted from 1 to 5 by post count",
+		Tags:        []string{"tags"},
+		Parameters:  []openapi.Parameter{openapi.QueryParam("limit", "integer", "Maximum number of tags, at most 100")},
+		Responses: map[string]openapi.Response{
+			"200": openapi.Returns("Tag cloud", b.ArrayOf(models.TagCloudEntry{})),
+		},
+	})
+
+	b.Add(http.MethodGet, "/tags/{tag}", openapi.Operation{
+		OperationID: "getTag",
+		Summary:     "Get a tag with its aliases",
+		Tags:        []string{"tags"},
+		Parameters:  []openapi.Parameter{tag},
+		Responses: map[string]openapi.Response{
+			"200": openapi.Returns("Tag", b.Ref(models.TagResponse{})),
+			"301": openapi.Returns("Alias or other spelling; Location holds the tag's slug", nil),
+			"404": fail("Tag not found"),
+		},
+	})
+
+	b.Add(http.MethodPut, "/tags/{tag}", openapi.Operation{
+		OperationID: "updateTag",
+		Summary:     "Rename or describe a tag; a renamed tag keeps its old slug as an alias",
+		Tags:        []string{"tags"},
+		Security:    openapi.BearerAuth,
+		Parameters:  []openapi.Parameter{tag},
+		RequestBody: openapi.JSON(b.Ref(models.TagUpdate{})),
+		Responses: map[string]openapi.Response{
+			"200": openapi.Returns("Tag updated", b.Ref(models.TagResponse{})),
+			"400": fail("Name has no letters or digits"),
+			"404": fail("Tag not found"),
+			"409": fail("Another tag has this name"),
+			"422": fail("Validation failed"),
+		},
+	})
+
+	b.Add(http.MethodPost, "/tags/{tag}/aliases", openapi.Operation{
+		OperationID: "addTagAlias",
+		Summary:     "Add an alias that resolves to the tag",
+		Tags:        []string{"tags"},
+		Security:    openapi.BearerAuth,
+		Parameters:  []openapi.Parameter{tag},
+		RequestBody: openapi.JSON(b.Ref(models.TagAlias{})),
+		Responses: map[string]openapi.Response{
+			"200": openapi.Returns("Tag with its aliases", b.Ref(models.TagResponse{})),
+			"404": fail("Tag not found"),
+			"409": fail("Alias is another tag; merge it instead"),
+			"422": fail("Validation failed"),
+		},
+	})
+
+	b.Add(http.MethodDelete, "/tags/{tag}/aliases/{alias}", openapi.Operation{
+		OperationID: "removeTagAlias",
+		Summary:     "Remove an alias",
+		Tags:        []string{"tags"},
+		Security:    openapi.BearerAuth,
+		Parameters:  []openapi.Parameter{tag, openapi.PathParam("alias", "string", "Alias")},
+		Responses: map[string]openapi.Response{
+			"204": openapi.Returns("Alias removed", nil),
+			"404": fail("Tag or alias not found"),
+		},
+	})
+
+	b.Add(http.MethodPost, "/tags/{tag}/merge", openapi.Operation{
+		OperationID: "mergeTag",
+		Summary:     "Merge the tag into another, which takes over its posts and aliases",
+		Tags:        []string{"tags"},
+		Security:    openapi.BearerAuth,
+		Parameters:  []openapi.Parameter{tag},
+		RequestBody: openapi.JSON(b.Ref(models.TagMerge{})),
+		Responses: map[string]openapi.Response{
+			"200": openapi.Returns("Tag merged into", b.Ref(models.TagResponse{})),
+			"404": fail("Tag not found"),
+			"409": fail("A tag cannot be merged into itself"),
+			"422": fail("Validation failed"),
+		},
+	})
+
+	seriesSlug := openapi.PathParam("slug", "string", "Series slug")
+
+	b.Add(http.MethodGet, "/series", openapi.Operation{
+		OperationID: "listSeries",
+		Summary:     "List series, newest first",
+		Tags:        []string{"series"},
+		Responses: map[string]openapi.Response{
+			"200": openapi.Returns("Series", b.ArrayOf(models.SeriesResponse{})),
+		},
+	})
+
+	b.Add(http.MethodPost, "/series", openapi.Operation{
+		OperationID: "createSeries",
+		Summary:     "Create a series, optionally with its first parts",
+		Tags:        []string{"series"},
+		Security:    openapi.BearerAuth,
+		RequestBody: openapi.JSON(b.Ref(models.SeriesCreate{})),
+		Responses: map[string]openapi.Response{
+			"201": openapi.Returns("Series created", b.Ref(models.SeriesResponse{})),
+			"400": fail("Malformed request body or a post listed twice"),
+			"403": fail("A post belongs to another author"),
+			"409": fail("A post is already part of a series"),
+			"422": fail("Validation failed or a post does not exist"),
+		},
+	})
+
+	b.Add(http.MethodGet, "/series/{slug}", openapi.Operation{
+		OperationID: "getSeries",
+		Summary:     "Get a series with its published parts in order",
+		Tags:        []string{"series"},
+		Parameters:  []openapi.Parameter{seriesSlug},
+		Responses: map[string]openapi.Response{
+			"200": openapi.Returns("Series", b.Ref(models.SeriesResponse{})),
+			"301": openapi.Returns("Series renamed; Location holds its current slug", nil),
+			"404": fail("Series not found"),
+		},
+	})
+
+	b.Add(http.MethodPut, "/series/{slug}", openapi.Operation{
+		OperationID: "updateSeries",
+		Summary:     "Update a series",
+		Tags:        []string{"series"},
+		Security:    openapi.BearerAuth,
+		Parameters:  []openapi.Parameter{seriesSlug},
+		RequestBody: openapi.JSON(b.Ref(models.SeriesUpdate{})),
+		Responses: map[string]openapi.Response{
+			"200": openapi.Returns("Series updated", b.Ref(models.SeriesResponse{})),
+			"403": fail("Series belongs to another author"),
+			"404": fail("Series not found"),
+			"422": fail("Validation failed"),
+		},
+	})
+
+	b.Add(http.MethodDelete, "/series/{slug}", openapi.Operation{
+		OperationID: "deleteSeries",
+		Summary:     "Delete a series; its posts are kept",
+		Tags:        []string{"series"},
+		Security:    openapi.BearerAuth,
+		Parameters:  []openapi.Parameter{seriesSlug},
+		Responses: map[string]openapi.Response{
+			"204": openapi.Returns("Series deleted", nil),
+			"403": fail("Series belongs to another author"),
+			"404": fail("Series not found"),
+		},
+	})
+
+	b.Add(http.MethodPost, "/series/{slug}/posts", openapi.Operation{
+		OperationID: "addSeriesPost",
+		Summary:     "Add a post to a series, as the last part unless part is given",
+		Tags:        []string{"series"},
+		Security:    openapi.BearerAuth,
+		Parameters:  []openapi.Parameter{seriesSlug},
+		RequestBody: openapi.JSON(b.Ref(models.SeriesPostAdd{})),
+		Responses: map[string]openapi.Response{
+			"200": openapi.Returns("Series", b.Ref(models.SeriesResponse{})),
+			"403": fail("Series or post belongs to another author"),
+			"404": fail("Series not found"),
+			"409": fail("Post is already part of a series"),
+			"422": fail("Validation failed or post does not exist"),
+		},
+	})
+
+	b.Add(http.MethodPut, "/series/{slug}/posts", openapi.Operation{
+		OperationID: "reorderSeriesPosts",
+		Summary:     "Reorder the parts of a series",
+		Tags:        []string{"series"},
+		Security:    openapi.BearerAuth,
+		Parameters:  []openapi.Parameter{seriesSlug},
+		RequestBody: openapi.JSON(b.Ref(models.SeriesReorder{})),
+		Responses: map[string]openapi.Response{
+			"200": openapi.Returns("Series", b.Ref(models.SeriesResponse{})),
+			"400": fail("Posts do not list every part of the series once"),
+			"403": fail("Series belongs to another author"),
+			"404": fail("Series not found"),
+			"422": fail("Validation failed"),
+		},
+	})
+
+	b.Add(http.MethodDelete, "/series/{slug}/posts/{post}", openapi.Operation{
+		OperationID: "removeSeriesPost",
+		Summary:     "Remove a post from a series; later parts move up",
+		Tags:        []string{"series"},
+		Security:    openapi.BearerAuth,
+		Parameters:  []openapi.Parameter{seriesSlug, openapi.PathParam("post", "integer", "Post ID")},
+		Responses: map[string]openapi.Response{
+			"204": openapi.Returns("Post removed", nil),
+			"403": fail("Series belongs to another author"),
+			"404": fail("Series not found or post not part of it"),
+		},
+	})
+
+	return b.Document()
+}
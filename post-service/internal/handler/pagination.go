@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// Headers describing a page of a listing
+const (
+	totalCountHeader = "X-Total-Count"
+	nextCursorHeader = "X-Next-Cursor"
+)
+
+// setPageHeaders reports the number of matching items and, when there is one,
+// the cursor of the next page, also as a Link rel="next". Links only carry a
+// query so they resolve against whatever path, such as the gateway's /v2, the
+// client used.
+func setPageHeaders(w http.ResponseWriter, r *http.Request, total int64, next string) {
+	w.Header().Set(totalCountHeader, strconv.FormatInt(total, 10))
+
+	query := r.URL.Query()
+	query.Del("page")
+	if query.Has("cursor") {
+		query.Del("cursor")
+		w.Header().Add("Link", fmt.Sprintf("<?%s>; rel=\"first\"", query.Encode()))
+	}
+
+	if next == "" {
+		return
+	}
+	w.Header().Set(nextCursorHeader, next)
+	query.Set("cursor", next)
+	w.Header().Add("Link", fmt.Sprintf("<?%s>; rel=\"next\"", query.Encode()))
+}
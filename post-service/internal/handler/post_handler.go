@@ -3,36 +3,46 @@ package handler
 import (
 	"encoding/json"
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
 
+	"github.com/Thedrogon/blogbish/Internals/problem"
+	"github.com/Thedrogon/blogbish/Internals/validation/rules"
 	"github.com/Thedrogon/blogbish/post-service/internal/models"
 	"github.com/Thedrogon/blogbish/post-service/internal/service"
 	"github.com/go-chi/chi/v5"
 )
 
 type PostHandler struct {
-	postService service.PostService
+	postService *service.PostService
 }
 
-func NewPostHandler(postService service.PostService) *PostHandler {
+func NewPostHandler(postService *service.PostService) *PostHandler {
 	return &PostHandler{
 		postService: postService,
-
-		
 	}
 }
 
 func (h *PostHandler) Create(w http.ResponseWriter, r *http.Request) {
-	var authorID int64
+	authorID, ok := caller(w, r)
+	if !ok {
+		return
+	}
+
 	var input models.PostCreate
 	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		problem.Error(w, r, http.StatusBadRequest, problem.CodeInvalidBody, "request body must be valid JSON")
+		return
+	}
+	if fields := rules.Struct(&input); fields != nil {
+		problem.Invalid(w, r, fields)
 		return
 	}
 
-	post, err := h.postService.CreatePost(r.Context(), &input , authorID)
+	post, err := h.postService.CreatePost(r.Context(), &input, authorID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problem.WriteError(w, r, problems, err)
 		return
 	}
 
@@ -44,17 +54,18 @@ func (h *PostHandler) Create(w http.ResponseWriter, r *http.Request) {
 func (h *PostHandler) Get(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	if id == "" {
-		http.Error(w, "Missing post ID", http.StatusBadRequest)
+		problem.Error(w, r, http.StatusBadRequest, problem.CodeInvalidInput, "post ID is required")
 		return
 	}
 
 	post, err := h.postService.GetPost(r.Context(), id)
 	if err != nil {
-		if err == service.ErrNotFound {
-			http.Error(w, "Post not found", http.StatusNotFound)
-			return
-		}
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problem.WriteError(w, r, problems, err)
+		return
+	}
+
+	if post.Slug != id {
+		redirectToSlug(w, r, post.Slug)
 		return
 	}
 
@@ -65,23 +76,27 @@ func (h *PostHandler) Get(w http.ResponseWriter, r *http.Request) {
 func (h *PostHandler) Update(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	if id == "" {
-		http.Error(w, "Missing post ID", http.StatusBadRequest)
+		problem.Error(w, r, http.StatusBadRequest, problem.CodeInvalidInput, "post ID is required")
+		return
+	}
+	authorID, ok := caller(w, r)
+	if !ok {
 		return
 	}
 
 	var input models.PostUpdate
 	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		problem.Error(w, r, http.StatusBadRequest, problem.CodeInvalidBody, "request body must be valid JSON")
+		return
+	}
+	if fields := rules.Struct(&input); fields != nil {
+		problem.Invalid(w, r, fields)
 		return
 	}
 
-	post, err := h.postService.UpdatePost(r.Context(), id, &input)
+	post, err := h.postService.UpdatePost(r.Context(), id, &input, authorID)
 	if err != nil {
-		if err == service.ErrNotFound {
-			http.Error(w, "Post not found", http.StatusNotFound)
-			return
-		}
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problem.WriteError(w, r, problems, err)
 		return
 	}
 
@@ -92,16 +107,16 @@ func (h *PostHandler) Update(w http.ResponseWriter, r *http.Request) {
 func (h *PostHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	if id == "" {
-		http.Error(w, "Missing post ID", http.StatusBadRequest)
+		problem.Error(w, r, http.StatusBadRequest, problem.CodeInvalidInput, "post ID is required")
+		return
+	}
+	authorID, ok := caller(w, r)
+	if !ok {
 		return
 	}
 
-	if err := h.postService.DeletePost(r.Context(), id); err != nil {
-		if err == service.ErrNotFound {
-			http.Error(w, "Post not found", http.StatusNotFound)
-			return
-		}
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	if err := h.postService.DeletePost(r.Context(), id, authorID); err != nil {
+		problem.WriteError(w, r, problems, err)
 		return
 	}
 
@@ -109,11 +124,9 @@ func (h *PostHandler) Delete(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *PostHandler) List(w http.ResponseWriter, r *http.Request) {
-	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
-	pageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
-	categorySlug := r.URL.Query().Get("category")
-	status := r.URL.Query().Get("status")
-	tag := r.URL.Query().Get("tag")
+	query := r.URL.Query()
+	page, _ := strconv.Atoi(query.Get("page"))
+	pageSize, _ := strconv.Atoi(query.Get("page_size"))
 
 	if page < 1 {
 		page = 1
@@ -123,19 +136,103 @@ func (h *PostHandler) List(w http.ResponseWriter, r *http.Request) {
 	}
 
 	filter := &models.PostFilter{
-		Page:         page,
-		PageSize:     pageSize,
-		CategorySlug: categorySlug,
-		Status:       status,
-		Tag:         tag,
+		Page:          page,
+		PageSize:      pageSize,
+		CategorySlugs: queryList(query, "category"),
+		Status:        query.Get("status"),
+		Tags:          queryList(query, "tag"),
+		SearchQuery:   strings.TrimSpace(query.Get("q")),
+		Sort:          query.Get("sort"),
+	}
+
+	invalid := func(detail string) {
+		problem.Error(w, r, http.StatusBadRequest, problem.CodeInvalidInput, detail)
+	}
+
+	switch query.Get("tag_match") {
+	case "", "any":
+	case "all":
+		filter.AllTags = true
+	default:
+		invalid("tag_match must be any or all")
+		return
+	}
+
+	if filter.Sort != "" && !slices.Contains(models.SortFields, filter.Sort) {
+		invalid("sort must be one of: " + strings.Join(models.SortFields, ", "))
+		return
+	}
+	if filter.SearchQuery != "" && filter.Sort == "" {
+		filter.Sort = models.SortRelevance
+	}
+	if filter.Sort == models.SortRelevance && filter.SearchQuery == "" {
+		invalid("sorting by relevance requires a search query")
+		return
+	}
+	// Titles read naturally A to Z, everything else newest or most first
+	switch query.Get("order") {
+	case "":
+		filter.Ascending = filter.Sort == models.SortTitle
+	case "asc":
+		filter.Ascending = true
+	case "desc":
+	default:
+		invalid("order must be asc or desc")
+		return
 	}
 
-	posts, err := h.postService.ListPosts(r.Context(), filter)
+	var ok bool
+	if filter.AuthorIDs, ok = queryIDs(query, "author"); !ok {
+		invalid("author must be a list of user IDs")
+		return
+	}
+	if filter.ExcludeIDs, ok = queryIDs(query, "exclude"); !ok {
+		invalid("exclude must be a list of post IDs")
+		return
+	}
+	if filter.PublishedAfter, ok = queryTime(query, "published_after"); !ok {
+		invalid("published_after must be an RFC 3339 timestamp or a date")
+		return
+	}
+	if filter.PublishedBefore, ok = queryTime(query, "published_before"); !ok {
+		invalid("published_before must be an RFC 3339 timestamp or a date")
+		return
+	}
+
+	if token := query.Get("cursor"); token != "" {
+		cursor, err := models.DecodeCursor(token)
+		if err != nil || !cursor.Matches(filter) {
+			invalid("cursor was issued for a different sort order or filters")
+			return
+		}
+		filter.Cursor = cursor
+	}
+
+	result, err := h.postService.ListPosts(r.Context(), filter)
+	if err != nil {
+		problem.WriteError(w, r, problems, err)
+		return
+	}
+
+	var next string
+	if result.Next != nil {
+		next = result.Next.Encode()
+	}
+	setPageHeaders(w, r, result.Total, next)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result.Posts)
+}
+
+func (h *PostHandler) ListScheduled(w http.ResponseWriter, r *http.Request) {
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	posts, err := h.postService.ListScheduled(r.Context(), limit)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problem.WriteError(w, r, problems, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(posts)
-} 
\ No newline at end of file
+}
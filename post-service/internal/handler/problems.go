@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Thedrogon/blogbish/Internals/problem"
+	"github.com/Thedrogon/blogbish/post-service/internal/service"
+)
+
+// Domain error codes clients can match on, besides the shared ones in problem
+const (
+	CodeInvalidStatus    = "invalid_status"
+	CodeInvalidOperation = "invalid_operation"
+	CodeSlugExists       = "slug_exists"
+	CodeCategoryNotFound = "category_not_found"
+	CodePostNotFound     = "post_not_found"
+	CodePublishAtInPast  = "publish_at_in_past"
+)
+
+// problems maps domain errors to the problem returned to clients
+var problems = problem.Mappings{
+	{Err: service.ErrNotFound, Status: http.StatusNotFound, Code: problem.CodeNotFound, Detail: "resource not found"},
+	{Err: service.ErrCategoryNotFound, Status: http.StatusUnprocessableEntity, Code: CodeCategoryNotFound, Detail: "category does not exist"},
+	{Err: service.ErrPostNotFound, Status: http.StatusUnprocessableEntity, Code: CodePostNotFound, Detail: "post does not exist"},
+	{Err: service.ErrSlugExists, Status: http.StatusConflict, Code: CodeSlugExists, Detail: "slug is already in use"},
+	{Err: service.ErrInvalidInput, Status: http.StatusBadRequest, Code: problem.CodeInvalidInput, Detail: "request is invalid"},
+	{Err: service.ErrInvalidStatus, Status: http.StatusBadRequest, Code: CodeInvalidStatus, Detail: "status is not valid for this operation"},
+	{Err: service.ErrInvalidOperation, Status: http.StatusConflict, Code: CodeInvalidOperation, Detail: "operation is not allowed in the current state"},
+	{Err: service.ErrUnauthorized, Status: http.StatusUnauthorized, Code: problem.CodeUnauthorized, Detail: "authentication is required"},
+	{Err: service.ErrForbidden, Status: http.StatusForbidden, Code: problem.CodeForbidden, Detail: "not allowed to modify this resource"},
+	{Err: service.ErrPublishAtInPast, Status: http.StatusUnprocessableEntity, Code: CodePublishAtInPast, Detail: "publish_at must be in the future"},
+}
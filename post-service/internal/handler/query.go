@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// queryList returns every value of a query parameter, accepting both repeated
+// parameters (?tag=a&tag=b) and comma-separated lists (?tag=a,b)
+func queryList(query url.Values, name string) []string {
+	var values []string
+	for _, value := range query[name] {
+		for _, v := range strings.Split(value, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
+	}
+	return values
+}
+
+// queryIDs parses a list of positive IDs, as queryList
+func queryIDs(query url.Values, name string) ([]int64, bool) {
+	var ids []int64
+	for _, value := range queryList(query, name) {
+		id, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || id <= 0 {
+			return nil, false
+		}
+		ids = append(ids, id)
+	}
+	return ids, true
+}
+
+// queryTime parses an RFC 3339 timestamp or a date, which stands for its
+// start in UTC; it returns the zero time when the parameter is absent
+func queryTime(query url.Values, name string) (time.Time, bool) {
+	value := query.Get(name)
+	if value == "" {
+		return time.Time{}, true
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, true
+	}
+	t, err := time.Parse(time.DateOnly, value)
+	return t, err == nil
+}
@@ -0,0 +1,19 @@
+package handler
+
+import (
+	"net/http"
+	"net/url"
+)
+
+// redirectToSlug answers a request for a resource by a former slug with 301
+// Moved Permanently to its current slug, the last segment of the path. The
+// Location is relative so it resolves against whatever prefix, such as the
+// gateway's /v2, the client used.
+func redirectToSlug(w http.ResponseWriter, r *http.Request, slug string) {
+	location := url.PathEscape(slug)
+	if r.URL.RawQuery != "" {
+		location += "?" + r.URL.RawQuery
+	}
+	w.Header().Set("Location", location)
+	w.WriteHeader(http.StatusMovedPermanently)
+}
@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Thedrogon/blogbish/Internals/problem"
+	"github.com/go-chi/chi/v5"
+)
+
+func (h *PostHandler) ListRevisions(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		problem.Error(w, r, http.StatusBadRequest, problem.CodeInvalidInput, "post ID is required")
+		return
+	}
+
+	revisions, err := h.postService.ListRevisions(r.Context(), id)
+	if err != nil {
+		problem.WriteError(w, r, problems, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(revisions)
+}
+
+func (h *PostHandler) GetRevision(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	revision, err := strconv.Atoi(chi.URLParam(r, "revision"))
+	if id == "" || err != nil || revision < 1 {
+		problem.Error(w, r, http.StatusBadRequest, problem.CodeInvalidInput, "post ID and a positive revision number are required")
+		return
+	}
+
+	rev, err := h.postService.GetRevision(r.Context(), id, revision)
+	if err != nil {
+		problem.WriteError(w, r, problems, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(rev)
+}
+
+// DiffRevisions compares ?from with ?to, which defaults to the latest
+// revision. Clients asking for text/x-diff get the patch without the JSON
+// envelope.
+func (h *PostHandler) DiffRevisions(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	from, err := strconv.Atoi(r.URL.Query().Get("from"))
+	if id == "" || err != nil || from < 1 {
+		problem.Error(w, r, http.StatusBadRequest, problem.CodeInvalidInput, "from must be a positive revision number")
+		return
+	}
+
+	to := 0
+	if raw := r.URL.Query().Get("to"); raw != "" {
+		if to, err = strconv.Atoi(raw); err != nil || to < 1 {
+			problem.Error(w, r, http.StatusBadRequest, problem.CodeInvalidInput, "to must be a positive revision number")
+			return
+		}
+	}
+
+	result, err := h.postService.DiffRevisions(r.Context(), id, from, to)
+	if err != nil {
+		problem.WriteError(w, r, problems, err)
+		return
+	}
+
+	if strings.Contains(r.Header.Get("Accept"), "text/x-diff") {
+		w.Header().Set("Content-Type", "text/x-diff; charset=utf-8")
+		w.Write([]byte(result.Diff))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+func (h *PostHandler) RestoreRevision(w http.ResponseWriter, r *http.Request) {
+	authorID, ok := caller(w, r)
+	if !ok {
+		return
+	}
+	id := chi.URLParam(r, "id")
+	revision, err := strconv.Atoi(chi.URLParam(r, "revision"))
+	if id == "" || err != nil || revision < 1 {
+		problem.Error(w, r, http.StatusBadRequest, problem.CodeInvalidInput, "post ID and a positive revision number are required")
+		return
+	}
+
+	post, err := h.postService.RestoreRevision(r.Context(), id, revision, authorID)
+	if err != nil {
+		problem.WriteError(w, r, problems, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(post)
+}
@@ -0,0 +1,191 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/Thedrogon/blogbish/Internals/problem"
+	"github.com/Thedrogon/blogbish/Internals/validation/rules"
+	"github.com/Thedrogon/blogbish/post-service/internal/models"
+	"github.com/Thedrogon/blogbish/post-service/internal/service"
+	"github.com/go-chi/chi/v5"
+)
+
+type SeriesHandler struct {
+	seriesService *service.SeriesService
+}
+
+func NewSeriesHandler(seriesService *service.SeriesService) *SeriesHandler {
+	return &SeriesHandler{
+		seriesService: seriesService,
+	}
+}
+
+func (h *SeriesHandler) Create(w http.ResponseWriter, r *http.Request) {
+	authorID, ok := caller(w, r)
+	if !ok {
+		return
+	}
+
+	var input models.SeriesCreate
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		problem.Error(w, r, http.StatusBadRequest, problem.CodeInvalidBody, "request body must be valid JSON")
+		return
+	}
+	if fields := rules.Struct(&input); fields != nil {
+		problem.Invalid(w, r, fields)
+		return
+	}
+
+	series, err := h.seriesService.CreateSeries(r.Context(), &input, authorID)
+	if err != nil {
+		problem.WriteError(w, r, problems, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(series)
+}
+
+func (h *SeriesHandler) List(w http.ResponseWriter, r *http.Request) {
+	list, err := h.seriesService.ListSeries(r.Context())
+	if err != nil {
+		problem.WriteError(w, r, problems, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(list)
+}
+
+func (h *SeriesHandler) Get(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+
+	series, err := h.seriesService.GetSeries(r.Context(), slug)
+	if err != nil {
+		problem.WriteError(w, r, problems, err)
+		return
+	}
+
+	if series.Slug != slug {
+		redirectToSlug(w, r, series.Slug)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(series)
+}
+
+func (h *SeriesHandler) Update(w http.ResponseWriter, r *http.Request) {
+	authorID, ok := caller(w, r)
+	if !ok {
+		return
+	}
+
+	var input models.SeriesUpdate
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		problem.Error(w, r, http.StatusBadRequest, problem.CodeInvalidBody, "request body must be valid JSON")
+		return
+	}
+	if fields := rules.Struct(&input); fields != nil {
+		problem.Invalid(w, r, fields)
+		return
+	}
+
+	series, err := h.seriesService.UpdateSeries(r.Context(), chi.URLParam(r, "slug"), &input, authorID)
+	if err != nil {
+		problem.WriteError(w, r, problems, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(series)
+}
+
+func (h *SeriesHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	authorID, ok := caller(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.seriesService.DeleteSeries(r.Context(), chi.URLParam(r, "slug"), authorID); err != nil {
+		problem.WriteError(w, r, problems, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *SeriesHandler) AddPost(w http.ResponseWriter, r *http.Request) {
+	authorID, ok := caller(w, r)
+	if !ok {
+		return
+	}
+
+	var input models.SeriesPostAdd
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		problem.Error(w, r, http.StatusBadRequest, problem.CodeInvalidBody, "request body must be valid JSON")
+		return
+	}
+	if fields := rules.Struct(&input); fields != nil {
+		problem.Invalid(w, r, fields)
+		return
+	}
+
+	series, err := h.seriesService.AddPost(r.Context(), chi.URLParam(r, "slug"), &input, authorID)
+	if err != nil {
+		problem.WriteError(w, r, problems, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(series)
+}
+
+func (h *SeriesHandler) RemovePost(w http.ResponseWriter, r *http.Request) {
+	authorID, ok := caller(w, r)
+	if !ok {
+		return
+	}
+
+	postID, err := strconv.ParseInt(chi.URLParam(r, "post"), 10, 64)
+	if err != nil || postID < 1 {
+		problem.Error(w, r, http.StatusBadRequest, problem.CodeInvalidInput, "post ID must be a positive integer")
+		return
+	}
+
+	if err := h.seriesService.RemovePost(r.Context(), chi.URLParam(r, "slug"), postID, authorID); err != nil {
+		problem.WriteError(w, r, problems, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *SeriesHandler) Reorder(w http.ResponseWriter, r *http.Request) {
+	authorID, ok := caller(w, r)
+	if !ok {
+		return
+	}
+
+	var input models.SeriesReorder
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		problem.Error(w, r, http.StatusBadRequest, problem.CodeInvalidBody, "request body must be valid JSON")
+		return
+	}
+	if fields := rules.Struct(&input); fields != nil {
+		problem.Invalid(w, r, fields)
+		return
+	}
+
+	series, err := h.seriesService.ReorderPosts(r.Context(), chi.URLParam(r, "slug"), &input, authorID)
+	if err != nil {
+		problem.WriteError(w, r, problems, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(series)
+}
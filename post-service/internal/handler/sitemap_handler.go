@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Thedrogon/blogbish/Internals/problem"
+	"github.com/Thedrogon/blogbish/post-service/internal/sitemap"
+	"github.com/go-chi/chi/v5"
+)
+
+func (h *PostHandler) SitemapIndex(w http.ResponseWriter, r *http.Request) {
+	h.serveSitemap(w, r, sitemap.Index)
+}
+
+func (h *PostHandler) Sitemap(w http.ResponseWriter, r *http.Request) {
+	h.serveSitemap(w, r, chi.URLParam(r, "name"))
+}
+
+func (h *PostHandler) serveSitemap(w http.ResponseWriter, r *http.Request, name string) {
+	doc, err := h.postService.Sitemap(r.Context(), name)
+	if err != nil {
+		problem.WriteError(w, r, problems, err)
+		return
+	}
+
+	serveDocument(w, r, doc)
+}
@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/Thedrogon/blogbish/Internals/problem"
+	"github.com/Thedrogon/blogbish/Internals/validation/rules"
+	"github.com/Thedrogon/blogbish/post-service/internal/models"
+	"github.com/Thedrogon/blogbish/post-service/internal/service"
+	"github.com/go-chi/chi/v5"
+)
+
+type TagHandler struct {
+	tagService *service.TagService
+}
+
+func NewTagHandler(tagService *service.TagService) *TagHandler {
+	return &TagHandler{
+		tagService: tagService,
+	}
+}
+
+func (h *TagHandler) List(w http.ResponseWriter, r *http.Request) {
+	tags, err := h.tagService.ListTags(r.Context())
+	if err != nil {
+		problem.WriteError(w, r, problems, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tags)
+}
+
+func (h *TagHandler) Cloud(w http.ResponseWriter, r *http.Request) {
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	entries, err := h.tagService.TagCloud(r.Context(), limit)
+	if err != nil {
+		problem.WriteError(w, r, problems, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(entries)
+}
+
+func (h *TagHandler) Get(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "tag")
+
+	tag, err := h.tagService.GetTag(r.Context(), slug)
+	if err != nil {
+		problem.WriteError(w, r, problems, err)
+		return
+	}
+
+	// Aliases and other spellings redirect to the tag's page
+	if tag.Slug != slug {
+		redirectToSlug(w, r, tag.Slug)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tag)
+}
+
+func (h *TagHandler) Update(w http.ResponseWriter, r *http.Request) {
+	var input models.TagUpdate
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		problem.Error(w, r, http.StatusBadRequest, problem.CodeInvalidBody, "request body must be valid JSON")
+		return
+	}
+	if fields := rules.Struct(&input); fields != nil {
+		problem.Invalid(w, r, fields)
+		return
+	}
+
+	tag, err := h.tagService.UpdateTag(r.Context(), chi.URLParam(r, "tag"), &input)
+	if err != nil {
+		problem.WriteError(w, r, problems, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tag)
+}
+
+func (h *TagHandler) AddAlias(w http.ResponseWriter, r *http.Request) {
+	var input models.TagAlias
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		problem.Error(w, r, http.StatusBadRequest, problem.CodeInvalidBody, "request body must be valid JSON")
+		return
+	}
+	if fields := rules.Struct(&input); fields != nil {
+		problem.Invalid(w, r, fields)
+		return
+	}
+
+	tag, err := h.tagService.AddAlias(r.Context(), chi.URLParam(r, "tag"), input.Alias)
+	if err != nil {
+		problem.WriteError(w, r, problems, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tag)
+}
+
+func (h *TagHandler) RemoveAlias(w http.ResponseWriter, r *http.Request) {
+	if err := h.tagService.RemoveAlias(r.Context(), chi.URLParam(r, "tag"), chi.URLParam(r, "alias")); err != nil {
+		problem.WriteError(w, r, problems, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *TagHandler) Merge(w http.ResponseWriter, r *http.Request) {
+	var input models.TagMerge
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		problem.Error(w, r, http.StatusBadRequest, problem.CodeInvalidBody, "request body must be valid JSON")
+		return
+	}
+	if fields := rules.Struct(&input); fields != nil {
+		problem.Invalid(w, r, fields)
+		return
+	}
+
+	tag, err := h.tagService.MergeTag(r.Context(), chi.URLParam(r, "tag"), &input)
+	if err != nil {
+		problem.WriteError(w, r, problems, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tag)
+}
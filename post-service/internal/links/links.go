@@ -0,0 +1,52 @@
+// Package links builds the public URLs of posts and media. They point at the
+// gateway's v2 API, since the services themselves aren't exposed.
+package links
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type Builder struct {
+	base string
+}
+
+// New returns a Builder for URLs under base, e.g. https://blog.example.com
+func New(base string) *Builder {
+	return &Builder{base: strings.TrimRight(base, "/")}
+}
+
+// Base is the site's root URL
+func (b *Builder) Base() string {
+	return b.base
+}
+
+func (b *Builder) Post(slug string) string {
+	return b.base + "/v2/posts/" + url.PathEscape(slug)
+}
+
+// Category is the canonical URL of a category, built like that of a post
+func (b *Builder) Category(slug string) string {
+	return b.base + "/v2/categories/" + url.PathEscape(slug)
+}
+
+// API returns the public URL of a v2 API path such as /posts/feed
+func (b *Builder) API(path string) string {
+	return b.base + "/v2" + path
+}
+
+// EntryID is a tag URI (RFC 4151) identifying a post in feeds. Unlike its
+// URL it survives changes of slug.
+func (b *Builder) EntryID(id int64, created time.Time) string {
+	host := b.base
+	if u, err := url.Parse(b.base); err == nil && u.Hostname() != "" {
+		host = u.Hostname()
+	}
+	return fmt.Sprintf("tag:%s,%s:posts/%d", host, created.UTC().Format("2006-01-02"), id)
+}
+
+func (b *Builder) Media(id string) string {
+	return b.base + "/v2/media/" + url.PathEscape(id) + "/download"
+}
@@ -7,6 +7,7 @@ type Category struct {
 	Name        string    `json:"name" db:"name"`
 	Slug        string    `json:"slug" db:"slug"`
 	Description string    `json:"description" db:"description"`
+	ParentID    *int64    `json:"parent_id,omitempty" db:"parent_id"` // nil for root categories
 	CreatedAt   time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
 }
@@ -14,6 +15,7 @@ type Category struct {
 type CategoryCreate struct {
 	Name        string `json:"name" validate:"required,min=2,max=50"`
 	Description string `json:"description" validate:"required"`
+	ParentID    *int64 `json:"parent_id,omitempty"`
 }
 
 type CategoryUpdate struct {
@@ -21,13 +23,29 @@ type CategoryUpdate struct {
 	Description string `json:"description,omitempty"`
 }
 
+// CategoryMove moves a category and its subtree under another parent, or to
+// the root when ParentID is null
+type CategoryMove struct {
+	ParentID *int64 `json:"parent_id"`
+}
+
+// Breadcrumb is one category on the path from the root to another
+type Breadcrumb struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
 type CategoryResponse struct {
-	ID          int64     `json:"id"`
-	Name        string    `json:"name"`
-	Slug        string    `json:"slug"`
-	Description string    `json:"description"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          int64               `json:"id"`
+	Name        string              `json:"name"`
+	Slug        string              `json:"slug"`
+	Description string              `json:"description"`
+	ParentID    *int64              `json:"parent_id,omitempty"`
+	Path        []Breadcrumb        `json:"path,omitempty"`     // ancestors, root first; only on single categories
+	Children    []*CategoryResponse `json:"children,omitempty"` // only in trees
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at"`
 }
 
 func (c *Category) ToResponse() *CategoryResponse {
@@ -36,7 +54,13 @@ func (c *Category) ToResponse() *CategoryResponse {
 		Name:        c.Name,
 		Slug:        c.Slug,
 		Description: c.Description,
+		ParentID:    c.ParentID,
 		CreatedAt:   c.CreatedAt,
 		UpdatedAt:   c.UpdatedAt,
 	}
 }
+
+// Breadcrumb returns c as a step of a category path
+func (c *Category) Breadcrumb() Breadcrumb {
+	return Breadcrumb{ID: c.ID, Name: c.Name, Slug: c.Slug}
+}
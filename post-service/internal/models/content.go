@@ -0,0 +1,46 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// Content formats a post can be written in
+const (
+	FormatMarkdown  = "markdown"
+	FormatHTML      = "html"
+	FormatPlaintext = "plaintext"
+)
+
+// TOCEntry is a heading in a post's table of contents
+type TOCEntry struct {
+	Level    int        `json:"level"`
+	ID       string     `json:"id"`
+	Title    string     `json:"title"`
+	Children []TOCEntry `json:"children,omitempty"`
+}
+
+// TOC is a nested table of contents, stored as JSONB
+type TOC []TOCEntry
+
+func (t TOC) Value() (driver.Value, error) {
+	if t == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(t)
+}
+
+func (t *TOC) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*t = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, t)
+	case string:
+		return json.Unmarshal([]byte(v), t)
+	default:
+		return fmt.Errorf("cannot scan %T into TOC", src)
+	}
+}
@@ -0,0 +1,136 @@
+package models
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// ErrInvalidCursor is returned for cursors that were not issued by the API
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor marks where a page of posts ends: the sort key and ID of its last
+// post, and the order and filters it was issued for. Clients only ever see it
+// encoded, as an opaque token.
+type Cursor struct {
+	Sort      string `json:"s"`
+	Ascending bool   `json:"a,omitempty"`
+	Key       string `json:"k"`
+	ID        int64  `json:"i"`
+
+	// Filter is the Fingerprint of the filter the cursor was issued for
+	Filter string `json:"f"`
+}
+
+// CursorAfter returns the cursor continuing filter's listing after post
+func CursorAfter(filter *PostFilter, post *Post) *Cursor {
+	return &Cursor{
+		Sort:      filter.SortField(),
+		Ascending: filter.Ascending,
+		Key:       post.SortKey(filter.SortField()),
+		ID:        post.ID,
+		Filter:    filter.Fingerprint(),
+	}
+}
+
+// Matches reports whether c continues a listing of filter's posts, in
+// filter's order
+func (c *Cursor) Matches(filter *PostFilter) bool {
+	return c.Sort == filter.SortField() && c.Ascending == filter.Ascending &&
+		c.Filter == filter.Fingerprint()
+}
+
+// Fingerprint identifies the posts filter selects, ignoring their order and
+// paging. Lists are compared as sets, so reordered query parameters still
+// match.
+func (f *PostFilter) Fingerprint() string {
+	key := struct {
+		AuthorIDs       []int64   `json:"a,omitempty"`
+		CategoryIDs     []int64   `json:"c,omitempty"`
+		CategorySlugs   []string  `json:"cs,omitempty"`
+		Status          string    `json:"st,omitempty"`
+		Tags            []string  `json:"t,omitempty"`
+		AllTags         bool      `json:"at,omitempty"`
+		SearchQuery     string    `json:"q,omitempty"`
+		PublishedAfter  time.Time `json:"pa"`
+		PublishedBefore time.Time `json:"pb"`
+		ExcludeIDs      []int64   `json:"x,omitempty"`
+	}{
+		AuthorIDs:       sorted(f.AuthorIDs),
+		CategoryIDs:     sorted(f.CategoryIDs),
+		CategorySlugs:   sorted(f.CategorySlugs),
+		Status:          f.Status,
+		Tags:            sorted(f.Tags),
+		AllTags:         f.AllTags,
+		SearchQuery:     f.SearchQuery,
+		PublishedAfter:  f.PublishedAfter.UTC(),
+		PublishedBefore: f.PublishedBefore.UTC(),
+		ExcludeIDs:      sorted(f.ExcludeIDs),
+	}
+
+	data, _ := json.Marshal(key)
+	sum := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(sum[:12])
+}
+
+// sorted returns a sorted copy of s
+func sorted[E cmp.Ordered](s []E) []E {
+	s = slices.Clone(s)
+	slices.Sort(s)
+	return s
+}
+
+// Encode returns the opaque token for c
+func (c *Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses a token returned by Encode
+func DecodeCursor(token string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var c Cursor
+	if err := json.Unmarshal(data, &c); err != nil || c.ID <= 0 || !c.validKey() {
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
+
+// validKey reports whether Key is a value of the Sort field
+func (c *Cursor) validKey() bool {
+	switch c.Sort {
+	case SortPublishedAt, SortUpdatedAt:
+		_, err := time.Parse(time.RFC3339Nano, c.Key)
+		return err == nil
+	case SortViewCount:
+		_, err := strconv.ParseInt(c.Key, 10, 64)
+		return err == nil
+	case SortRelevance:
+		_, err := strconv.ParseFloat(c.Key, 32)
+		return err == nil
+	case SortTitle:
+		return true
+	default:
+		return false
+	}
+}
+
+// PostPage is one page of a post listing
+type PostPage struct {
+	Posts []*PostResponse
+
+	// Total counts every post matching the filter, across all pages
+	Total int64
+
+	// Next continues the listing; nil on the last page
+	Next *Cursor
+}
@@ -0,0 +1,132 @@
+package models
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	post := &Post{
+		ID:          42,
+		Title:       "Hello, world",
+		ViewCount:   7,
+		PublishedAt: time.Date(2024, 5, 6, 7, 8, 9, 123456789, time.UTC),
+		UpdatedAt:   time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+		Rank:        0.25,
+	}
+
+	for _, sort := range SortFields {
+		t.Run(sort, func(t *testing.T) {
+			filter := &PostFilter{Sort: sort, Ascending: true, Tags: []string{"go"}}
+			cursor := CursorAfter(filter, post)
+
+			decoded, err := DecodeCursor(cursor.Encode())
+			if err != nil {
+				t.Fatalf("DecodeCursor() error = %v", err)
+			}
+			if *decoded != *cursor {
+				t.Errorf("decoded %+v, want %+v", decoded, cursor)
+			}
+			if !decoded.Matches(filter) {
+				t.Error("cursor does not match the filter it was issued for")
+			}
+		})
+	}
+}
+
+func TestDecodeCursorTampered(t *testing.T) {
+	encode := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"not base64", "not a cursor!"},
+		{"not json", encode("cursor")},
+		{"missing id", encode(`{"s":"title","k":"a"}`)},
+		{"negative id", encode(`{"s":"title","k":"a","i":-1}`)},
+		{"id of the wrong type", encode(`{"s":"title","k":"a","i":"1"}`)},
+		{"unknown sort", encode(`{"s":"author_id","k":"1","i":1}`)},
+		{"missing sort", encode(`{"k":"a","i":1}`)},
+		{"time key not a time", encode(`{"s":"published_at","k":"yesterday","i":1}`)},
+		{"count key not a number", encode(`{"s":"view_count","k":"1; DROP TABLE posts","i":1}`)},
+		{"relevance key not a number", encode(`{"s":"relevance","k":"high","i":1}`)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if c, err := DecodeCursor(tt.token); err != ErrInvalidCursor {
+				t.Errorf("DecodeCursor() = %+v, %v; want ErrInvalidCursor", c, err)
+			}
+		})
+	}
+}
+
+func TestCursorWithoutFilterDoesNotMatch(t *testing.T) {
+	token := base64.RawURLEncoding.EncodeToString([]byte(`{"s":"title","k":"a","i":1}`))
+	cursor, err := DecodeCursor(token)
+	if err != nil {
+		t.Fatalf("DecodeCursor() error = %v", err)
+	}
+	if cursor.Matches(&PostFilter{Sort: SortTitle}) {
+		t.Error("cursor without a filter fingerprint matches")
+	}
+}
+
+func TestCursorMatches(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	issued := &PostFilter{
+		AuthorIDs:       []int64{1, 2},
+		CategorySlugs:   []string{"go", "rust"},
+		Status:          "published",
+		Tags:            []string{"web", "api"},
+		SearchQuery:     "server",
+		PublishedAfter:  day(1),
+		PublishedBefore: day(31),
+		ExcludeIDs:      []int64{9},
+		Sort:            SortViewCount,
+		Page:            1,
+		PageSize:        10,
+	}
+	cursor := CursorAfter(issued, &Post{ID: 3, ViewCount: 5})
+
+	tests := []struct {
+		name   string
+		change func(f *PostFilter)
+		want   bool
+	}{
+		{"same filter", func(f *PostFilter) {}, true},
+		{"lists reordered", func(f *PostFilter) {
+			f.AuthorIDs = []int64{2, 1}
+			f.CategorySlugs = []string{"rust", "go"}
+			f.Tags = []string{"api", "web"}
+		}, true},
+		{"paging changed", func(f *PostFilter) { f.Page, f.PageSize = 3, 20 }, true},
+		{"date range in another zone", func(f *PostFilter) {
+			f.PublishedAfter = day(1).In(time.FixedZone("EST", -5*3600))
+		}, true},
+		{"sort changed", func(f *PostFilter) { f.Sort = SortTitle }, false},
+		{"direction changed", func(f *PostFilter) { f.Ascending = true }, false},
+		{"author added", func(f *PostFilter) { f.AuthorIDs = append(f.AuthorIDs, 3) }, false},
+		{"category changed", func(f *PostFilter) { f.CategorySlugs = []string{"go"} }, false},
+		{"category ID added", func(f *PostFilter) { f.CategoryIDs = []int64{4} }, false},
+		{"status changed", func(f *PostFilter) { f.Status = "draft" }, false},
+		{"tag changed", func(f *PostFilter) { f.Tags = []string{"web", "cli"} }, false},
+		{"all tags required", func(f *PostFilter) { f.AllTags = true }, false},
+		{"query changed", func(f *PostFilter) { f.SearchQuery = "client" }, false},
+		{"date range moved", func(f *PostFilter) { f.PublishedBefore = day(30) }, false},
+		{"date range dropped", func(f *PostFilter) { f.PublishedAfter = time.Time{} }, false},
+		{"exclusion dropped", func(f *PostFilter) { f.ExcludeIDs = nil }, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := *issued
+			tt.change(&filter)
+			if got := cursor.Matches(&filter); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
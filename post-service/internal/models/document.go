@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// Document is an encoded feed or sitemap, cached with the validators it is
+// served with
+type Document struct {
+	Body         []byte    `json:"body"`
+	ContentType  string    `json:"content_type"`
+	ETag         string    `json:"etag"`
+	LastModified time.Time `json:"last_modified"`
+}
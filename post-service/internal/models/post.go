@@ -1,78 +1,193 @@
 package models
 
 import (
+	"strconv"
 	"time"
 )
 
 type Post struct {
-	ID          int64     `json:"id" db:"id"`
-	Title       string    `json:"title" db:"title"`
-	Content     string    `json:"content" db:"content"`
-	Slug        string    `json:"slug" db:"slug"`
-	AuthorID    int64     `json:"author_id" db:"author_id"`
-	CategoryID  int64     `json:"category_id" db:"category_id"`
-	Status      string    `json:"status" db:"status"` // draft, published, archived
-	Tags        []string  `json:"tags" db:"tags"`
-	ViewCount   int64     `json:"view_count" db:"view_count"`
-	PublishedAt time.Time `json:"published_at,omitempty" db:"published_at"`
-	CreatedAt   time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+	ID            int64      `json:"id" db:"id"`
+	Title         string     `json:"title" db:"title"`
+	Content       string     `json:"content" db:"content"`
+	ContentFormat string     `json:"content_format" db:"content_format"`
+	ContentHTML   string     `json:"content_html" db:"content_html"`
+	TOC           TOC        `json:"toc" db:"toc"`
+	Excerpt       string     `json:"excerpt" db:"excerpt"`
+	ReadingTime   int        `json:"reading_time" db:"reading_time"` // minutes
+	Slug          string     `json:"slug" db:"slug"`
+	AuthorID      int64      `json:"author_id" db:"author_id"`
+	CategoryID    int64      `json:"category_id" db:"category_id"`
+	Status        string     `json:"status" db:"status"` // draft, scheduled, published, archived
+	Tags          []string   `json:"tags" db:"tags"`
+	ViewCount     int64      `json:"view_count" db:"view_count"`
+	PublishedAt   time.Time  `json:"published_at,omitempty" db:"published_at"`
+	PublishAt     *time.Time `json:"publish_at,omitempty" db:"publish_at"` // set while scheduled
+	Meta          PostMeta   `json:"meta"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at" db:"updated_at"`
+
+	// Set on search results only
+	Rank      float32 `json:"-" db:"-"`
+	Highlight string  `json:"-" db:"-"`
 }
 
 type PostCreate struct {
-	Title      string   `json:"title" validate:"required,min=3,max=255"`
-	Content    string   `json:"content" validate:"required"`
-	CategoryID int64    `json:"category_id" validate:"required"`
-	Tags       []string `json:"tags,omitempty"`
-	Status     string   `json:"status" validate:"required,oneof=draft published"`
+	Title         string     `json:"title" validate:"required,min=3,max=255"`
+	Content       string     `json:"content" validate:"required"`
+	ContentFormat string     `json:"content_format,omitempty" validate:"omitempty,oneof=markdown html plaintext"`
+	CategoryID    int64      `json:"category_id" validate:"required"`
+	Tags          []string   `json:"tags,omitempty"`
+	Status        string     `json:"status" validate:"required,oneof=draft published scheduled"`
+	PublishAt     *time.Time `json:"publish_at,omitempty" validate:"required_if=Status scheduled"`
+	PostMeta
 }
 
 type PostUpdate struct {
-	Title      string   `json:"title,omitempty" validate:"omitempty,min=3,max=255"`
-	Content    string   `json:"content,omitempty"`
-	CategoryID int64    `json:"category_id,omitempty"`
-	Tags       []string `json:"tags,omitempty"`
-	Status     string   `json:"status,omitempty" validate:"omitempty,oneof=draft published archived"`
+	Title         string     `json:"title,omitempty" validate:"omitempty,min=3,max=255"`
+	Content       string     `json:"content,omitempty"`
+	ContentFormat string     `json:"content_format,omitempty" validate:"omitempty,oneof=markdown html plaintext"`
+	CategoryID    int64      `json:"category_id,omitempty"`
+	Tags          []string   `json:"tags,omitempty"`
+	Status        string     `json:"status,omitempty" validate:"omitempty,oneof=draft published archived scheduled"`
+	PublishAt     *time.Time `json:"publish_at,omitempty" validate:"required_if=Status scheduled"`
+	PostMeta
+}
+
+// PostMeta holds the SEO fields authors may set on a post. Empty fields fall
+// back to defaults derived from the post; see SEO.
+type PostMeta struct {
+	MetaTitle       string `json:"meta_title,omitempty" db:"meta_title" validate:"omitempty,max=70"`
+	MetaDescription string `json:"meta_description,omitempty" db:"meta_description" validate:"omitempty,max=320"`
+	CanonicalURL    string `json:"canonical_url,omitempty" db:"canonical_url" validate:"omitempty,url,max=2048"`
+	OGImageID       string `json:"og_image_id,omitempty" db:"og_image_id" validate:"omitempty,max=64"` // media-service ID
+}
+
+// SEO is the metadata pages embed for a post, with defaults filled in
+type SEO struct {
+	Title        string `json:"title"`
+	Description  string `json:"description"`
+	CanonicalURL string `json:"canonical_url"`
+	Image        string `json:"image,omitempty"`
 }
 
 type PostResponse struct {
-	ID          int64     `json:"id"`
-	Title       string    `json:"title"`
-	Content     string    `json:"content"`
-	Slug        string    `json:"slug"`
-	AuthorID    int64     `json:"author_id"`
-	CategoryID  int64     `json:"category_id"`
-	Status      string    `json:"status"`
-	Tags        []string  `json:"tags"`
-	ViewCount   int64     `json:"view_count"`
-	PublishedAt time.Time `json:"published_at,omitempty"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID            int64      `json:"id"`
+	Title         string     `json:"title"`
+	Content       string     `json:"content"`
+	ContentFormat string     `json:"content_format"`
+	ContentHTML   string     `json:"content_html"`
+	TOC           TOC        `json:"toc"`
+	Excerpt       string     `json:"excerpt"`
+	ReadingTime   int        `json:"reading_time"` // minutes
+	Slug          string     `json:"slug"`
+	AuthorID      int64      `json:"author_id"`
+	CategoryID    int64      `json:"category_id"`
+	Status        string     `json:"status"`
+	Tags          []string   `json:"tags"`
+	ViewCount     int64      `json:"view_count"`
+	PublishedAt   time.Time  `json:"published_at,omitempty"`
+	PublishAt     *time.Time `json:"publish_at,omitempty"`
+	Meta          PostMeta   `json:"meta"`
+	SEO           *SEO       `json:"seo,omitempty"`       // only on single posts
+	Series        *SeriesNav `json:"series,omitempty"`    // only on single posts
+	Highlight     string     `json:"highlight,omitempty"` // only on search results
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
 }
 
+// Fields post listings can be sorted by
+const (
+	SortPublishedAt = "published_at"
+	SortViewCount   = "view_count"
+	SortTitle       = "title"
+	SortUpdatedAt   = "updated_at"
+	SortRelevance   = "relevance" // search results only
+)
+
+// SortFields lists every field post listings can be sorted by
+var SortFields = []string{SortPublishedAt, SortViewCount, SortTitle, SortUpdatedAt, SortRelevance}
+
 type PostFilter struct {
-	AuthorID    int64    `json:"author_id,omitempty"`
-	CategoryID  int64    `json:"category_id,omitempty"`
+	AuthorIDs   []int64  `json:"author_ids,omitempty"`
+	CategoryIDs []int64  `json:"category_ids,omitempty"`
 	Status      string   `json:"status,omitempty"`
 	Tags        []string `json:"tags,omitempty"`
-	SearchQuery string   `json:"search_query,omitempty"`
+	SearchQuery string   `json:"search_query,omitempty"` // web search syntax: "quoted phrases", or, -excluded
 	Page        int      `json:"page,omitempty"`
 	PageSize    int      `json:"page_size,omitempty"`
+
+	// AllTags matches posts carrying every tag rather than any of them
+	AllTags bool `json:"all_tags,omitempty"`
+
+	// Publication date range; PublishedAfter is inclusive, PublishedBefore
+	// exclusive, and either may be zero
+	PublishedAfter  time.Time `json:"published_after,omitempty"`
+	PublishedBefore time.Time `json:"published_before,omitempty"`
+
+	ExcludeIDs []int64 `json:"exclude_ids,omitempty"`
+
+	// Sort is one of SortFields, published_at when empty, and relevance
+	// requires a SearchQuery. Ties are broken by ID in the same direction.
+	Sort      string `json:"sort,omitempty"`
+	Ascending bool   `json:"ascending,omitempty"`
+
+	// CategorySlugs are resolved and added to CategoryIDs by the service
+	CategorySlugs []string `json:"-"`
+
+	// Cursor continues a listing after a previous page and takes precedence
+	// over Page
+	Cursor *Cursor `json:"-"`
+
+	// Lookahead fetches one row past the page, to tell whether another
+	// page follows, without moving the page offset
+	Lookahead bool `json:"-"`
+}
+
+// SortField returns the field posts are sorted by
+func (f *PostFilter) SortField() string {
+	if f.Sort == "" {
+		return SortPublishedAt
+	}
+	return f.Sort
+}
+
+// SortKey returns the value of field for p, as stored in cursors
+func (p *Post) SortKey(field string) string {
+	switch field {
+	case SortViewCount:
+		return strconv.FormatInt(p.ViewCount, 10)
+	case SortTitle:
+		return p.Title
+	case SortUpdatedAt:
+		return p.UpdatedAt.Format(time.RFC3339Nano)
+	case SortRelevance:
+		return strconv.FormatFloat(float64(p.Rank), 'g', -1, 32)
+	default:
+		return p.PublishedAt.Format(time.RFC3339Nano)
+	}
 }
 
 func (p *Post) ToResponse() *PostResponse {
 	return &PostResponse{
-		ID:          p.ID,
-		Title:       p.Title,
-		Content:     p.Content,
-		Slug:        p.Slug,
-		AuthorID:    p.AuthorID,
-		CategoryID:  p.CategoryID,
-		Status:      p.Status,
-		Tags:        p.Tags,
-		ViewCount:   p.ViewCount,
-		PublishedAt: p.PublishedAt,
-		CreatedAt:   p.CreatedAt,
-		UpdatedAt:   p.UpdatedAt,
+		ID:            p.ID,
+		Title:         p.Title,
+		Content:       p.Content,
+		ContentFormat: p.ContentFormat,
+		ContentHTML:   p.ContentHTML,
+		TOC:           p.TOC,
+		Excerpt:       p.Excerpt,
+		ReadingTime:   p.ReadingTime,
+		Slug:          p.Slug,
+		AuthorID:      p.AuthorID,
+		CategoryID:    p.CategoryID,
+		Status:        p.Status,
+		Tags:          p.Tags,
+		ViewCount:     p.ViewCount,
+		PublishedAt:   p.PublishedAt,
+		PublishAt:     p.PublishAt,
+		Meta:          p.Meta,
+		Highlight:     p.Highlight,
+		CreatedAt:     p.CreatedAt,
+		UpdatedAt:     p.UpdatedAt,
 	}
 }
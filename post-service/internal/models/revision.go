@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+// PostRevision is a snapshot of the editable fields of a post. Revision 1 is
+// the post as created; every update adds the next revision.
+type PostRevision struct {
+	ID            int64     `json:"id" db:"id"`
+	PostID        int64     `json:"post_id" db:"post_id"`
+	Revision      int       `json:"revision" db:"revision"`
+	AuthorID      int64     `json:"author_id" db:"author_id"`
+	Title         string    `json:"title" db:"title"`
+	Content       string    `json:"content" db:"content"`
+	ContentFormat string    `json:"content_format" db:"content_format"`
+	Tags          []string  `json:"tags" db:"tags"`
+	CategoryID    int64     `json:"category_id" db:"category_id"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+}
+
+type PostRevisionResponse struct {
+	Revision      int       `json:"revision"`
+	AuthorID      int64     `json:"author_id"`
+	Title         string    `json:"title"`
+	Content       string    `json:"content"`
+	ContentFormat string    `json:"content_format"`
+	Tags          []string  `json:"tags"`
+	CategoryID    int64     `json:"category_id"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// RevisionDiff is a unified diff between two revisions of a post
+type RevisionDiff struct {
+	From int    `json:"from"`
+	To   int    `json:"to"`
+	Diff string `json:"diff"`
+}
+
+func (r *PostRevision) ToResponse() *PostRevisionResponse {
+	return &PostRevisionResponse{
+		Revision:      r.Revision,
+		AuthorID:      r.AuthorID,
+		Title:         r.Title,
+		Content:       r.Content,
+		ContentFormat: r.ContentFormat,
+		Tags:          r.Tags,
+		CategoryID:    r.CategoryID,
+		CreatedAt:     r.CreatedAt,
+	}
+}
@@ -0,0 +1,83 @@
+package models
+
+import "time"
+
+// Series is an ordered multi-part collection of posts, such as a tutorial
+type Series struct {
+	ID          int64     `json:"id" db:"id"`
+	Title       string    `json:"title" db:"title"`
+	Slug        string    `json:"slug" db:"slug"`
+	Description string    `json:"description" db:"description"`
+	AuthorID    int64     `json:"author_id" db:"author_id"`
+	Parts       int       `json:"parts" db:"-"` // posts in the series, published or not
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+}
+
+type SeriesCreate struct {
+	Title       string  `json:"title" validate:"required,min=3,max=255"`
+	Description string  `json:"description,omitempty"`
+	PostIDs     []int64 `json:"post_ids,omitempty"` // initial parts, in order
+}
+
+type SeriesUpdate struct {
+	Title       string `json:"title,omitempty" validate:"omitempty,min=3,max=255"`
+	Description string `json:"description,omitempty"`
+}
+
+// SeriesPostAdd adds a post to a series as part Part, moving later parts
+// back, or as the last part when Part is zero
+type SeriesPostAdd struct {
+	PostID int64 `json:"post_id" validate:"required,min=1"`
+	Part   int   `json:"part,omitempty" validate:"omitempty,min=1"`
+}
+
+// SeriesReorder lists every post of a series in its new order
+type SeriesReorder struct {
+	PostIDs []int64 `json:"post_ids" validate:"required,min=1"`
+}
+
+// SeriesPart is a post of a series; parts are numbered from 1
+type SeriesPart struct {
+	ID     int64  `json:"id"`
+	Title  string `json:"title"`
+	Slug   string `json:"slug"`
+	Part   int    `json:"part"`
+	Status string `json:"-"`
+}
+
+// SeriesNav places a post within its series
+type SeriesNav struct {
+	ID       int64       `json:"id"`
+	Title    string      `json:"title"`
+	Slug     string      `json:"slug"`
+	Part     int         `json:"part"`
+	Parts    int         `json:"parts"`
+	Previous *SeriesPart `json:"previous,omitempty"` // nearest published part before
+	Next     *SeriesPart `json:"next,omitempty"`     // nearest published part after
+}
+
+type SeriesResponse struct {
+	ID          int64        `json:"id"`
+	Title       string       `json:"title"`
+	Slug        string       `json:"slug"`
+	Description string       `json:"description"`
+	AuthorID    int64        `json:"author_id"`
+	Parts       int          `json:"parts"`
+	Posts       []SeriesPart `json:"posts,omitempty"` // published parts; only on single series
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+func (s *Series) ToResponse() *SeriesResponse {
+	return &SeriesResponse{
+		ID:          s.ID,
+		Title:       s.Title,
+		Slug:        s.Slug,
+		Description: s.Description,
+		AuthorID:    s.AuthorID,
+		Parts:       s.Parts,
+		CreatedAt:   s.CreatedAt,
+		UpdatedAt:   s.UpdatedAt,
+	}
+}
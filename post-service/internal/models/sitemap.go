@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// SitemapPage is a non-empty sitemap with the last change to its entries
+type SitemapPage struct {
+	Page         int
+	LastModified time.Time
+}
+
+// SitemapEntry is a post or category listed in a sitemap
+type SitemapEntry struct {
+	Slug         string
+	LastModified time.Time
+}
@@ -0,0 +1,62 @@
+package models
+
+import "time"
+
+// Tag is the canonical record of a tag. Posts store tags by slug.
+type Tag struct {
+	ID          int64     `json:"id" db:"id"`
+	Name        string    `json:"name" db:"name"`
+	Slug        string    `json:"slug" db:"slug"`
+	Description string    `json:"description" db:"description"`
+	PostCount   int64     `json:"post_count" db:"-"` // published posts only
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+}
+
+type TagUpdate struct {
+	Name        string `json:"name,omitempty" validate:"omitempty,min=1,max=50"`
+	Description string `json:"description,omitempty"`
+}
+
+type TagAlias struct {
+	Alias string `json:"alias" validate:"required,max=50"`
+}
+
+type TagMerge struct {
+	Into string `json:"into" validate:"required"` // slug of the tag to keep
+}
+
+type TagResponse struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	Slug        string    `json:"slug"`
+	Description string    `json:"description"`
+	PostCount   int64     `json:"post_count"`
+	Aliases     []string  `json:"aliases,omitempty"` // only on single tags
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// TagCloudEntry is a tag weighted by how many posts carry it, from 1 for the
+// least used to CloudWeights for the most
+type TagCloudEntry struct {
+	Name      string `json:"name"`
+	Slug      string `json:"slug"`
+	PostCount int64  `json:"post_count"`
+	Weight    int    `json:"weight"`
+}
+
+// CloudWeights is the number of weights in a tag cloud
+const CloudWeights = 5
+
+func (t *Tag) ToResponse() *TagResponse {
+	return &TagResponse{
+		ID:          t.ID,
+		Name:        t.Name,
+		Slug:        t.Slug,
+		Description: t.Description,
+		PostCount:   t.PostCount,
+		CreatedAt:   t.CreatedAt,
+		UpdatedAt:   t.UpdatedAt,
+	}
+}
@@ -0,0 +1,57 @@
+package render
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// MoreMarker ends the excerpt of a post where the author wants it to end
+const MoreMarker = "<!--more-->"
+
+// maxExcerptLength caps excerpts, in characters, when the first paragraph
+// runs long
+const maxExcerptLength = 300
+
+// excerpt returns the plain text of the first paragraph of doc, or of all
+// of doc when whole is set because the author marked where it ends
+func excerpt(doc string, whole bool) string {
+	body := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+	nodes, err := html.ParseFragment(strings.NewReader(doc), body)
+	if err != nil {
+		return ""
+	}
+
+	var parts []string
+	for _, n := range nodes {
+		if !whole && n.Type == html.ElementNode && n.DataAtom == atom.P {
+			if text := textOf(n); strings.TrimSpace(text) != "" {
+				parts = []string{text}
+				break
+			}
+		}
+		parts = append(parts, textOf(n))
+	}
+
+	return Truncate(strings.Join(strings.Fields(strings.Join(parts, " ")), " "), maxExcerptLength)
+}
+
+// Truncate shortens text to at most n characters, cutting at a word
+// boundary where possible
+func Truncate(text string, n int) string {
+	if utf8.RuneCountInString(text) <= n {
+		return text
+	}
+	// Leave room for the ellipsis, and drop the word the cut splits unless it
+	// falls just before a space
+	runes := []rune(text)
+	cut := string(runes[:n-1])
+	if runes[n-1] != ' ' {
+		if i := strings.LastIndex(cut, " "); i > 0 {
+			cut = cut[:i]
+		}
+	}
+	return strings.TrimRight(cut, " ,.;:") + "…"
+}
@@ -0,0 +1,75 @@
+package render
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/Thedrogon/blogbish/post-service/internal/models"
+)
+
+func TestExcerpt(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		content string
+		want    string
+	}{
+		{"first paragraph", models.FormatMarkdown, "# Title\n\nFirst *para*.\n\nSecond.", "First para."},
+		{"skips empty paragraphs", models.FormatHTML, "<p> </p><p>Real text</p>", "Real text"},
+		{"no paragraph", models.FormatMarkdown, "# Only a heading", "Only a heading"},
+		{"whitespace collapsed", models.FormatHTML, "<p>a\n\n   b\tc</p>", "a b c"},
+		{"more marker", models.FormatMarkdown, "First.\n\nSecond.\n\n<!--more-->\n\nThird.", "First. Second."},
+		{"plaintext", models.FormatPlaintext, "one\ntwo\n\nthree", "one two"},
+		{"entities decoded", models.FormatMarkdown, "Fish &amp; chips", "Fish & chips"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Render(tt.format, tt.content)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if result.Excerpt != tt.want {
+				t.Errorf("Excerpt = %q, want %q", result.Excerpt, tt.want)
+			}
+		})
+	}
+}
+
+func TestExcerptLength(t *testing.T) {
+	result, err := Render(models.FormatMarkdown, strings.Repeat("wörd ", 200))
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if n := utf8.RuneCountInString(result.Excerpt); n > maxExcerptLength {
+		t.Errorf("excerpt has %d characters, want at most %d", n, maxExcerptLength)
+	}
+	if !strings.HasSuffix(result.Excerpt, "wörd…") {
+		t.Errorf("excerpt %q does not end on a whole word", result.Excerpt)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		n    int
+		want string
+	}{
+		{"short", "hello", 10, "hello"},
+		{"exact", "hello", 5, "hello"},
+		{"word boundary", "hello brave new world", 12, "hello brave…"},
+		{"trailing punctuation", "one, two, three", 10, "one, two…"},
+		{"single long word", "abcdefghij", 5, "abcd…"},
+		{"multibyte", "ééé ééé", 5, "ééé…"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Truncate(tt.text, tt.n); got != tt.want {
+				t.Errorf("Truncate(%q, %d) = %q, want %q", tt.text, tt.n, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package render
+
+import (
+	chromahtml "github.com/alecthomas/chroma/v2/formatters/html"
+	"github.com/yuin/goldmark"
+	highlighting "github.com/yuin/goldmark-highlighting/v2"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/renderer/html"
+)
+
+// highlightStyle is the chroma style used for fenced code blocks
+const highlightStyle = "github"
+
+// markdown renders CommonMark with GitHub extensions. Raw HTML is passed
+// through and left to the sanitizer, so authors can still embed markup the
+// policy allows.
+var markdown = goldmark.New(
+	goldmark.WithExtensions(
+		extension.GFM,
+		extension.Footnote,
+		highlighting.NewHighlighting(
+			highlighting.WithStyle(highlightStyle),
+			highlighting.WithFormatOptions(
+				chromahtml.WithClasses(false),
+				chromahtml.TabWidth(4),
+			),
+		),
+	),
+	goldmark.WithRendererOptions(
+		html.WithUnsafe(),
+	),
+)
@@ -0,0 +1,137 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Thedrogon/blogbish/post-service/internal/models"
+	"github.com/Thedrogon/blogbish/post-service/internal/utils"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var headingLevels = map[atom.Atom]int{
+	atom.H1: 1, atom.H2: 2, atom.H3: 3, atom.H4: 4, atom.H5: 5, atom.H6: 6,
+}
+
+// outline gives every heading in doc an ID and a self-link, and returns the
+// updated document with its table of contents and word count
+func outline(doc string) (string, models.TOC, int, error) {
+	body := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+	nodes, err := html.ParseFragment(strings.NewReader(doc), body)
+	if err != nil {
+		return "", nil, 0, fmt.Errorf("error parsing rendered html: %w", err)
+	}
+
+	var (
+		toc   = tocBuilder{entries: models.TOC{}}
+		words int
+		ids   = make(map[string]int)
+	)
+
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		switch {
+		case n.Type == html.TextNode:
+			words += len(strings.Fields(n.Data))
+		case n.Type == html.ElementNode && headingLevels[n.DataAtom] > 0:
+			title := strings.Join(strings.Fields(textOf(n)), " ")
+			words += len(strings.Fields(title))
+			if title == "" {
+				return
+			}
+			id := uniqueID(ids, utils.GenerateSlug(title))
+			setAttr(n, "id", id)
+			n.AppendChild(anchor(id))
+			toc.add(headingLevels[n.DataAtom], id, title)
+			return
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+
+	var out strings.Builder
+	for _, n := range nodes {
+		walk(n)
+		if err := html.Render(&out, n); err != nil {
+			return "", nil, 0, fmt.Errorf("error rendering html: %w", err)
+		}
+	}
+
+	return out.String(), toc.entries, words, nil
+}
+
+// uniqueID suffixes repeated heading slugs the way GitHub does
+func uniqueID(seen map[string]int, id string) string {
+	if id == "" {
+		id = "section"
+	}
+	n := seen[id]
+	seen[id] = n + 1
+	if n == 0 {
+		return id
+	}
+	return fmt.Sprintf("%s-%d", id, n)
+}
+
+// anchor is the self-link appended to headings so readers can share them
+func anchor(id string) *html.Node {
+	a := &html.Node{
+		Type:     html.ElementNode,
+		Data:     "a",
+		DataAtom: atom.A,
+		Attr: []html.Attribute{
+			{Key: "href", Val: "#" + id},
+			{Key: "class", Val: "anchor"},
+			{Key: "aria-label", Val: "Permalink"},
+		},
+	}
+	a.AppendChild(&html.Node{Type: html.TextNode, Data: "#"})
+	return a
+}
+
+func textOf(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	var b strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		b.WriteString(textOf(c))
+	}
+	return b.String()
+}
+
+func setAttr(n *html.Node, key, val string) {
+	for i, attr := range n.Attr {
+		if attr.Key == key {
+			n.Attr[i].Val = val
+			return
+		}
+	}
+	n.Attr = append(n.Attr, html.Attribute{Key: key, Val: val})
+}
+
+// tocBuilder nests headings under the closest preceding heading of a
+// higher level
+type tocBuilder struct {
+	entries models.TOC
+	stack   []*models.TOCEntry
+}
+
+func (t *tocBuilder) add(level int, id, title string) {
+	for len(t.stack) > 0 && t.stack[len(t.stack)-1].Level >= level {
+		t.stack = t.stack[:len(t.stack)-1]
+	}
+
+	entry := models.TOCEntry{Level: level, ID: id, Title: title}
+	if len(t.stack) == 0 {
+		t.entries = append(t.entries, entry)
+		t.stack = append(t.stack, &t.entries[len(t.entries)-1])
+		return
+	}
+
+	parent := t.stack[len(t.stack)-1]
+	parent.Children = append(parent.Children, entry)
+	t.stack = append(t.stack, &parent.Children[len(parent.Children)-1])
+}
@@ -0,0 +1,86 @@
+package render
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Thedrogon/blogbish/post-service/internal/models"
+)
+
+func TestOutline(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		want models.TOC
+	}{
+		{"no headings", "<p>text</p>", models.TOC{}},
+		{
+			name: "nested",
+			doc:  "<h1>Intro</h1><h2>Setup</h2><h3>Linux</h3><h2>Usage</h2><h1>End</h1>",
+			want: models.TOC{
+				{Level: 1, ID: "intro", Title: "Intro", Children: []models.TOCEntry{
+					{Level: 2, ID: "setup", Title: "Setup", Children: []models.TOCEntry{
+						{Level: 3, ID: "linux", Title: "Linux"},
+					}},
+					{Level: 2, ID: "usage", Title: "Usage"},
+				}},
+				{Level: 1, ID: "end", Title: "End"},
+			},
+		},
+		{
+			name: "starts below the top level",
+			doc:  "<h3>Deep</h3><h2>Shallower</h2><h3>Child</h3>",
+			want: models.TOC{
+				{Level: 3, ID: "deep", Title: "Deep"},
+				{Level: 2, ID: "shallower", Title: "Shallower", Children: []models.TOCEntry{
+					{Level: 3, ID: "child", Title: "Child"},
+				}},
+			},
+		},
+		{
+			name: "repeated titles",
+			doc:  "<h2>Notes</h2><h2>Notes</h2><h2>Notes</h2>",
+			want: models.TOC{
+				{Level: 2, ID: "notes", Title: "Notes"},
+				{Level: 2, ID: "notes-1", Title: "Notes"},
+				{Level: 2, ID: "notes-2", Title: "Notes"},
+			},
+		},
+		{
+			name: "markup and whitespace in titles",
+			doc:  "<h2>  Using <code>go   test</code>\n</h2><h2>!!!</h2><h2></h2>",
+			want: models.TOC{
+				{Level: 2, ID: "using-go-test", Title: "Using go test"},
+				{Level: 2, ID: "section", Title: "!!!"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, toc, _, err := outline(tt.doc)
+			if err != nil {
+				t.Fatalf("outline() error = %v", err)
+			}
+			if !reflect.DeepEqual(toc, tt.want) {
+				t.Errorf("toc = %+v, want %+v", toc, tt.want)
+			}
+		})
+	}
+}
+
+func TestOutlineAnchors(t *testing.T) {
+	doc, _, words, err := outline(`<h2 id="old">Getting started</h2><p>Two words</p>`)
+	if err != nil {
+		t.Fatalf("outline() error = %v", err)
+	}
+
+	want := `<h2 id="getting-started">Getting started<a href="#getting-started" class="anchor" aria-label="Permalink">#</a></h2>`
+	if !strings.HasPrefix(doc, want) {
+		t.Errorf("doc = %q, want prefix %q", doc, want)
+	}
+	if words != 4 {
+		t.Errorf("words = %d, want 4", words)
+	}
+}
@@ -0,0 +1,111 @@
+// Package render turns post content into sanitized HTML with a table of
+// contents, an excerpt and a reading-time estimate
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"math"
+	"strings"
+
+	"github.com/Thedrogon/blogbish/post-service/internal/models"
+)
+
+// wordsPerMinute is the reading speed used for estimates
+const wordsPerMinute = 200
+
+// Result is the rendered form of a post's content
+type Result struct {
+	HTML        string
+	TOC         models.TOC
+	Excerpt     string // plain text
+	WordCount   int
+	ReadingTime int // minutes
+}
+
+// Render converts content written in format to safe HTML. Whatever the
+// format, the output is sanitized, so it can be embedded in pages as is.
+func Render(format, content string) (*Result, error) {
+	// Everything before the more marker is the excerpt; without one the
+	// first paragraph is used
+	head, tail, marked := strings.Cut(content, MoreMarker)
+	if marked {
+		content = head + tail
+	}
+
+	raw, err := toHTML(format, content)
+	if err != nil {
+		return nil, err
+	}
+	sanitized := policy.Sanitize(raw)
+
+	summary := sanitized
+	if marked {
+		if summary, err = toHTML(format, head); err != nil {
+			return nil, err
+		}
+		summary = policy.Sanitize(summary)
+	}
+
+	// Anchors are added after sanitizing so every heading gets a stable,
+	// unique ID whatever the source format produced
+	doc, toc, words, err := outline(sanitized)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{
+		HTML:        doc,
+		TOC:         toc,
+		Excerpt:     excerpt(summary, marked),
+		WordCount:   words,
+		ReadingTime: readingTime(words),
+	}, nil
+}
+
+// toHTML converts content written in format to unsanitized HTML
+func toHTML(format, content string) (string, error) {
+	switch format {
+	case models.FormatMarkdown, "":
+		var buf bytes.Buffer
+		if err := markdown.Convert([]byte(content), &buf); err != nil {
+			return "", fmt.Errorf("error rendering markdown: %w", err)
+		}
+		return buf.String(), nil
+	case models.FormatHTML:
+		return content, nil
+	case models.FormatPlaintext:
+		return plaintext(content), nil
+	default:
+		return "", fmt.Errorf("unknown content format %q", format)
+	}
+}
+
+// plaintext escapes text and keeps its paragraphs and line breaks
+func plaintext(content string) string {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+
+	var b strings.Builder
+	for _, para := range strings.Split(content, "\n\n") {
+		para = strings.Trim(para, "\n")
+		if strings.TrimSpace(para) == "" {
+			continue
+		}
+		lines := strings.Split(para, "\n")
+		for i := range lines {
+			lines[i] = html.EscapeString(lines[i])
+		}
+		b.WriteString("<p>")
+		b.WriteString(strings.Join(lines, "<br>\n"))
+		b.WriteString("</p>\n")
+	}
+	return b.String()
+}
+
+func readingTime(words int) int {
+	if words == 0 {
+		return 0
+	}
+	return int(math.Ceil(float64(words) / wordsPerMinute))
+}
@@ -0,0 +1,128 @@
+package render
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Thedrogon/blogbish/post-service/internal/models"
+)
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   string
+		content  string
+		contains []string
+		excludes []string
+	}{
+		{
+			name:     "markdown",
+			format:   models.FormatMarkdown,
+			content:  "Some *emphasis* and `code`.\n\n- one\n- two\n",
+			contains: []string{"<em>emphasis</em>", "<code>code</code>", "<li>one</li>"},
+		},
+		{
+			name:     "empty format is markdown",
+			content:  "**bold**",
+			contains: []string{"<strong>bold</strong>"},
+		},
+		{
+			name:     "gfm table and strikethrough",
+			format:   models.FormatMarkdown,
+			content:  "| a | b |\n|---|---|\n| 1 | 2 |\n\n~~gone~~\n",
+			contains: []string{"<table>", "<td>1</td>", "<del>gone</del>"},
+		},
+		{
+			name:     "highlighted code",
+			format:   models.FormatMarkdown,
+			content:  "```go\nfunc main() {}\n```\n",
+			contains: []string{"<pre", `style="`, "main"},
+			excludes: []string{"class=\"chroma"},
+		},
+		{
+			name:     "footnotes",
+			format:   models.FormatMarkdown,
+			content:  "Claim[^1].\n\n[^1]: Source.\n",
+			contains: []string{`class="footnote-ref"`, `class="footnotes"`},
+		},
+		{
+			name:     "raw html in markdown is sanitized",
+			format:   models.FormatMarkdown,
+			content:  "<script>alert(1)</script>\n\n<a href=\"javascript:alert(1)\" onclick=\"x()\">link</a>\n",
+			contains: []string{"link"},
+			excludes: []string{"<script", "javascript:", "onclick"},
+		},
+		{
+			name:     "html",
+			format:   models.FormatHTML,
+			content:  `<p>Hi <img src="x.png" onerror="x()"></p>`,
+			contains: []string{"<p>Hi <img src=\"x.png\"/></p>"},
+			excludes: []string{"onerror"},
+		},
+		{
+			name:     "plaintext",
+			format:   models.FormatPlaintext,
+			content:  "a < b\nsecond line\n\n\n\nnext paragraph",
+			contains: []string{"<p>a &lt; b<br/>\nsecond line</p>", "<p>next paragraph</p>"},
+		},
+		{
+			name:     "more marker is dropped",
+			format:   models.FormatMarkdown,
+			content:  "Intro.\n\n<!--more-->\n\nRest.",
+			contains: []string{"Intro.", "Rest."},
+			excludes: []string{"more"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Render(tt.format, tt.content)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			for _, want := range tt.contains {
+				if !strings.Contains(result.HTML, want) {
+					t.Errorf("HTML %q does not contain %q", result.HTML, want)
+				}
+			}
+			for _, unwanted := range tt.excludes {
+				if strings.Contains(result.HTML, unwanted) {
+					t.Errorf("HTML %q contains %q", result.HTML, unwanted)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderUnknownFormat(t *testing.T) {
+	if _, err := Render("rst", "text"); err == nil {
+		t.Error("Render() with an unknown format succeeded")
+	}
+}
+
+func TestRenderWordCount(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		words       int
+		readingTime int
+	}{
+		{"empty", "", 0, 0},
+		{"short", "# Title\n\nThree more words", 4, 1},
+		{"one minute", strings.Repeat("word ", wordsPerMinute), wordsPerMinute, 1},
+		{"rounds up", strings.Repeat("word ", wordsPerMinute+1), wordsPerMinute + 1, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Render(models.FormatMarkdown, tt.content)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if result.WordCount != tt.words || result.ReadingTime != tt.readingTime {
+				t.Errorf("got %d words, %d minutes; want %d words, %d minutes",
+					result.WordCount, result.ReadingTime, tt.words, tt.readingTime)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package render
+
+import (
+	"regexp"
+
+	"github.com/microcosm-cc/bluemonday"
+)
+
+// policy allows the markup authors and the Markdown renderer produce and
+// strips scripts, event handlers, javascript: URLs and the like
+var policy = newPolicy()
+
+func newPolicy() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+
+	// Inline styles from syntax highlighting, limited to harmless properties
+	p.AllowStyles("color", "background-color", "font-weight", "font-style", "text-decoration").
+		OnElements("span", "pre")
+	p.AllowStyles("tab-size", "-moz-tab-size", "white-space").OnElements("pre")
+
+	// Footnote and task list markup
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^(footnotes|footnote-ref|footnote-backref|task-list-item)$`)).
+		OnElements("div", "a", "sup", "li", "section")
+	p.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	p.AllowAttrs("checked", "disabled").OnElements("input")
+	p.AllowAttrs("role").Matching(regexp.MustCompile(`^doc-(endnotes|noteref|backlink)$`)).
+		OnElements("div", "a", "section")
+	p.AllowAttrs("id").Matching(regexp.MustCompile(`^fn(ref)?:?[\w-]+$`)).OnElements("li", "sup")
+
+	return p
+}
@@ -3,12 +3,17 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/Thedrogon/blogbish/post-service/internal/models"
 )
 
+// ErrCategoryCycle is returned by Move when the new parent lies in the
+// category's own subtree
+var ErrCategoryCycle = errors.New("category cannot be moved below itself")
+
 type CategoryRepository interface {
 	Create(ctx context.Context, category *models.Category) error
 	GetByID(ctx context.Context, id int64) (*models.Category, error)
@@ -16,6 +21,11 @@ type CategoryRepository interface {
 	List(ctx context.Context) ([]*models.Category, error)
 	Update(ctx context.Context, category *models.Category) error
 	Delete(ctx context.Context, id int64) error
+	Ancestors(ctx context.Context, id int64) ([]*models.Category, error)
+	Descendants(ctx context.Context, id int64) ([]int64, error)
+	Move(ctx context.Context, id int64, parentID *int64) error
+	SitemapPages(ctx context.Context, size int) ([]models.SitemapPage, error)
+	SitemapEntries(ctx context.Context, page, size int) ([]models.SitemapEntry, error)
 }
 
 type PostgresCategoryRepository struct {
@@ -28,8 +38,8 @@ func NewPostgresCategoryRepository(db *sql.DB) *PostgresCategoryRepository {
 
 func (r *PostgresCategoryRepository) Create(ctx context.Context, category *models.Category) error {
 	query := `
-		INSERT INTO categories (name, slug, description, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO categories (name, slug, description, parent_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
 		RETURNING id`
 
 	now := time.Now()
@@ -42,6 +52,7 @@ func (r *PostgresCategoryRepository) Create(ctx context.Context, category *model
 		category.Name,
 		category.Slug,
 		category.Description,
+		category.ParentID,
 		category.CreatedAt,
 		category.UpdatedAt,
 	).Scan(&category.ID)
@@ -55,7 +66,7 @@ func (r *PostgresCategoryRepository) Create(ctx context.Context, category *model
 
 func (r *PostgresCategoryRepository) GetByID(ctx context.Context, id int64) (*models.Category, error) {
 	query := `
-		SELECT id, name, slug, description, created_at, updated_at
+		SELECT id, name, slug, description, parent_id, created_at, updated_at
 		FROM categories
 		WHERE id = $1`
 
@@ -65,6 +76,7 @@ func (r *PostgresCategoryRepository) GetByID(ctx context.Context, id int64) (*mo
 		&category.Name,
 		&category.Slug,
 		&category.Description,
+		&category.ParentID,
 		&category.CreatedAt,
 		&category.UpdatedAt,
 	)
@@ -80,11 +92,12 @@ func (r *PostgresCategoryRepository) GetByID(ctx context.Context, id int64) (*mo
 	return category, nil
 }
 
+// GetBySlug finds a category by its current slug or a former one
 func (r *PostgresCategoryRepository) GetBySlug(ctx context.Context, slug string) (*models.Category, error) {
 	query := `
-		SELECT id, name, slug, description, created_at, updated_at
+		SELECT id, name, slug, description, parent_id, created_at, updated_at
 		FROM categories
-		WHERE slug = $1`
+		` + categorySlugs.bySlug("$1")
 
 	category := &models.Category{}
 	err := r.db.QueryRowContext(ctx, query, slug).Scan(
@@ -92,6 +105,7 @@ func (r *PostgresCategoryRepository) GetBySlug(ctx context.Context, slug string)
 		&category.Name,
 		&category.Slug,
 		&category.Description,
+		&category.ParentID,
 		&category.CreatedAt,
 		&category.UpdatedAt,
 	)
@@ -109,7 +123,7 @@ func (r *PostgresCategoryRepository) GetBySlug(ctx context.Context, slug string)
 
 func (r *PostgresCategoryRepository) List(ctx context.Context) ([]*models.Category, error) {
 	query := `
-		SELECT id, name, slug, description, created_at, updated_at
+		SELECT id, name, slug, description, parent_id, created_at, updated_at
 		FROM categories
 		ORDER BY name ASC`
 
@@ -127,6 +141,7 @@ func (r *PostgresCategoryRepository) List(ctx context.Context) ([]*models.Catego
 			&category.Name,
 			&category.Slug,
 			&category.Description,
+			&category.ParentID,
 			&category.CreatedAt,
 			&category.UpdatedAt,
 		)
@@ -151,7 +166,17 @@ func (r *PostgresCategoryRepository) Update(ctx context.Context, category *model
 
 	category.UpdatedAt = time.Now()
 
-	result, err := r.db.ExecContext(
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := categorySlugs.rename(ctx, tx, category.ID, category.Slug); err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(
 		ctx,
 		query,
 		category.Name,
@@ -174,6 +199,10 @@ func (r *PostgresCategoryRepository) Update(ctx context.Context, category *model
 		return fmt.Errorf("category not found")
 	}
 
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing category: %w", err)
+	}
+
 	return nil
 }
 
@@ -196,3 +225,141 @@ func (r *PostgresCategoryRepository) Delete(ctx context.Context, id int64) error
 
 	return nil
 }
+
+// Ancestors returns the categories above id, root first
+func (r *PostgresCategoryRepository) Ancestors(ctx context.Context, id int64) ([]*models.Category, error) {
+	query := `
+		WITH RECURSIVE ancestors AS (
+			SELECT parent_id, 0 AS depth FROM categories WHERE id = $1
+			UNION ALL
+			SELECT c.parent_id, a.depth + 1
+			FROM categories c
+			JOIN ancestors a ON c.id = a.parent_id
+		)
+		SELECT c.id, c.name, c.slug, c.description, c.parent_id, c.created_at, c.updated_at
+		FROM ancestors a
+		JOIN categories c ON c.id = a.parent_id
+		ORDER BY a.depth DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("error getting category ancestors: %w", err)
+	}
+	defer rows.Close()
+
+	var categories []*models.Category
+	for rows.Next() {
+		category := &models.Category{}
+		err := rows.Scan(
+			&category.ID,
+			&category.Name,
+			&category.Slug,
+			&category.Description,
+			&category.ParentID,
+			&category.CreatedAt,
+			&category.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning category: %w", err)
+		}
+		categories = append(categories, category)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating category ancestors: %w", err)
+	}
+
+	return categories, nil
+}
+
+// Descendants returns the IDs of id and of every category below it
+func (r *PostgresCategoryRepository) Descendants(ctx context.Context, id int64) ([]int64, error) {
+	query := `
+		WITH RECURSIVE subtree AS (
+			SELECT id FROM categories WHERE id = $1
+			UNION ALL
+			SELECT c.id
+			FROM categories c
+			JOIN subtree s ON c.parent_id = s.id
+		)
+		SELECT id FROM subtree`
+
+	rows, err := r.db.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("error getting category descendants: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("error scanning category id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating category descendants: %w", err)
+	}
+
+	return ids, nil
+}
+
+// Move puts category id, with its subtree, under parentID, or at the root
+// when parentID is nil. Moves that would make a category its own ancestor are
+// refused.
+func (r *PostgresCategoryRepository) Move(ctx context.Context, id int64, parentID *int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Serialize moves, so two of them cannot each pass the cycle check and
+	// close a loop together. Reads are not blocked.
+	if _, err := tx.ExecContext(ctx, `LOCK TABLE categories IN SHARE ROW EXCLUSIVE MODE`); err != nil {
+		return fmt.Errorf("error locking categories: %w", err)
+	}
+
+	if parentID != nil {
+		cycle := `
+			WITH RECURSIVE ancestors AS (
+				SELECT id, parent_id FROM categories WHERE id = $1
+				UNION ALL
+				SELECT c.id, c.parent_id
+				FROM categories c
+				JOIN ancestors a ON c.id = a.parent_id
+			)
+			SELECT EXISTS (SELECT 1 FROM ancestors WHERE id = $2)`
+
+		var loops bool
+		if err := tx.QueryRowContext(ctx, cycle, *parentID, id).Scan(&loops); err != nil {
+			return fmt.Errorf("error checking category ancestors: %w", err)
+		}
+		if loops {
+			return ErrCategoryCycle
+		}
+	}
+
+	query := `UPDATE categories SET parent_id = $1, updated_at = $2 WHERE id = $3`
+	result, err := tx.ExecContext(ctx, query, parentID, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("error moving category: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error getting rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("category not found")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing category move: %w", err)
+	}
+
+	return nil
+}
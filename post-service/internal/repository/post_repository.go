@@ -15,12 +15,21 @@ type PostRepository interface {
 	Create(ctx context.Context, post *models.Post) error
 	GetByID(ctx context.Context, id int64) (*models.Post, error)
 	GetBySlug(ctx context.Context, slug string) (*models.Post, error)
-	Update(ctx context.Context, post *models.Post) error
+	Update(ctx context.Context, post *models.Post, editorID int64) error
 	Delete(ctx context.Context, id int64) error
 	List(ctx context.Context, filter *models.PostFilter) ([]*models.Post, error)
+	Count(ctx context.Context, filter *models.PostFilter) (int64, error)
 	IncrementViewCount(ctx context.Context, id int64) error
+	ListScheduled(ctx context.Context, limit int) ([]*models.Post, error)
+	PublishDue(ctx context.Context, now time.Time, limit int) ([]*models.Post, error)
+	SitemapPages(ctx context.Context, size int) ([]models.SitemapPage, error)
+	SitemapEntries(ctx context.Context, page, size int) ([]models.SitemapEntry, error)
 }
 
+// postColumns lists the columns read by scanPost, in order
+const postColumns = "id, title, content, content_format, content_html, toc, excerpt, reading_time, slug, author_id, category_id, status, tags, view_count, published_at, publish_at, " +
+	"meta_title, meta_description, canonical_url, og_image_id, created_at, updated_at"
+
 type PostgresPostRepository struct {
 	db *sql.DB
 }
@@ -31,25 +40,42 @@ func NewPostgresPostRepository(db *sql.DB) *PostgresPostRepository {
 
 func (r *PostgresPostRepository) Create(ctx context.Context, post *models.Post) error {
 	query := `
-		INSERT INTO posts (title, content, slug, author_id, category_id, status, tags, published_at, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		INSERT INTO posts (title, content, content_format, content_html, toc, excerpt, reading_time, slug, author_id, category_id, status, tags,
+			published_at, publish_at, meta_title, meta_description, canonical_url, og_image_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)
 		RETURNING id`
 
 	now := time.Now()
 	post.CreatedAt = now
 	post.UpdatedAt = now
 
-	err := r.db.QueryRowContext(
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(
 		ctx,
 		query,
 		post.Title,
 		post.Content,
+		post.ContentFormat,
+		post.ContentHTML,
+		post.TOC,
+		post.Excerpt,
+		post.ReadingTime,
 		post.Slug,
 		post.AuthorID,
 		post.CategoryID,
 		post.Status,
 		pq.Array(post.Tags),
 		post.PublishedAt,
+		post.PublishAt,
+		post.Meta.MetaTitle,
+		post.Meta.MetaDescription,
+		post.Meta.CanonicalURL,
+		post.Meta.OGImageID,
 		post.CreatedAt,
 		post.UpdatedAt,
 	).Scan(&post.ID)
@@ -58,30 +84,24 @@ func (r *PostgresPostRepository) Create(ctx context.Context, post *models.Post)
 		return fmt.Errorf("error creating post: %w", err)
 	}
 
+	if err := insertRevision(ctx, tx, post, post.AuthorID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing post: %w", err)
+	}
+
 	return nil
 }
 
 func (r *PostgresPostRepository) GetByID(ctx context.Context, id int64) (*models.Post, error) {
 	query := `
-		SELECT id, title, content, slug, author_id, category_id, status, tags, view_count, published_at, created_at, updated_at
+		SELECT ` + postColumns + `
 		FROM posts
 		WHERE id = $1`
 
-	post := &models.Post{}
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&post.ID,
-		&post.Title,
-		&post.Content,
-		&post.Slug,
-		&post.AuthorID,
-		&post.CategoryID,
-		&post.Status,
-		pq.Array(&post.Tags),
-		&post.ViewCount,
-		&post.PublishedAt,
-		&post.CreatedAt,
-		&post.UpdatedAt,
-	)
+	post, err := scanPost(r.db.QueryRowContext(ctx, query, id))
 
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("post not found")
@@ -94,27 +114,15 @@ func (r *PostgresPostRepository) GetByID(ctx context.Context, id int64) (*models
 	return post, nil
 }
 
+// GetBySlug finds a post by its current slug or one it had before being
+// renamed; callers can tell the two apart by comparing post.Slug
 func (r *PostgresPostRepository) GetBySlug(ctx context.Context, slug string) (*models.Post, error) {
 	query := `
-		SELECT id, title, content, slug, author_id, category_id, status, tags, view_count, published_at, created_at, updated_at
+		SELECT ` + postColumns + `
 		FROM posts
-		WHERE slug = $1`
+		` + postSlugs.bySlug("$1")
 
-	post := &models.Post{}
-	err := r.db.QueryRowContext(ctx, query, slug).Scan(
-		&post.ID,
-		&post.Title,
-		&post.Content,
-		&post.Slug,
-		&post.AuthorID,
-		&post.CategoryID,
-		&post.Status,
-		pq.Array(&post.Tags),
-		&post.ViewCount,
-		&post.PublishedAt,
-		&post.CreatedAt,
-		&post.UpdatedAt,
-	)
+	post, err := scanPost(r.db.QueryRowContext(ctx, query, slug))
 
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("post not found")
@@ -127,23 +135,47 @@ func (r *PostgresPostRepository) GetBySlug(ctx context.Context, slug string) (*m
 	return post, nil
 }
 
-func (r *PostgresPostRepository) Update(ctx context.Context, post *models.Post) error {
+// Update saves post and records the change as a new revision by editorID
+func (r *PostgresPostRepository) Update(ctx context.Context, post *models.Post, editorID int64) error {
 	query := `
 		UPDATE posts
-		SET title = $1, content = $2, slug = $3, category_id = $4, status = $5, tags = $6, updated_at = $7
-		WHERE id = $8`
+		SET title = $1, content = $2, content_format = $3, content_html = $4, toc = $5, excerpt = $6, reading_time = $7,
+			slug = $8, category_id = $9, status = $10, tags = $11, published_at = $12, publish_at = $13,
+			meta_title = $14, meta_description = $15, canonical_url = $16, og_image_id = $17, updated_at = $18
+		WHERE id = $19`
 
 	post.UpdatedAt = time.Now()
 
-	result, err := r.db.ExecContext(
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := postSlugs.rename(ctx, tx, post.ID, post.Slug); err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(
 		ctx,
 		query,
 		post.Title,
 		post.Content,
+		post.ContentFormat,
+		post.ContentHTML,
+		post.TOC,
+		post.Excerpt,
+		post.ReadingTime,
 		post.Slug,
 		post.CategoryID,
 		post.Status,
 		pq.Array(post.Tags),
+		post.PublishedAt,
+		post.PublishAt,
+		post.Meta.MetaTitle,
+		post.Meta.MetaDescription,
+		post.Meta.CanonicalURL,
+		post.Meta.OGImageID,
 		post.UpdatedAt,
 		post.ID,
 	)
@@ -161,6 +193,14 @@ func (r *PostgresPostRepository) Update(ctx context.Context, post *models.Post)
 		return fmt.Errorf("post not found")
 	}
 
+	if err := insertRevision(ctx, tx, post, editorID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing post: %w", err)
+	}
+
 	return nil
 }
 
@@ -184,54 +224,73 @@ func (r *PostgresPostRepository) Delete(ctx context.Context, id int64) error {
 	return nil
 }
 
-func (r *PostgresPostRepository) List(ctx context.Context, filter *models.PostFilter) ([]*models.Post, error) {
-	var conditions []string
-	var args []interface{}
-	argPosition := 1
+// sortTypes maps the fields posts can be sorted by to their column types, so
+// cursor keys can be cast back
+var sortTypes = map[string]string{
+	models.SortPublishedAt: "timestamptz",
+	models.SortViewCount:   "bigint",
+	models.SortTitle:       "text",
+	models.SortUpdatedAt:   "timestamptz",
+	models.SortRelevance:   "real",
+}
 
-	query := `
-		SELECT id, title, content, slug, author_id, category_id, status, tags, view_count, published_at, created_at, updated_at
-		FROM posts`
+func (r *PostgresPostRepository) List(ctx context.Context, filter *models.PostFilter) ([]*models.Post, error) {
+	conditions, args := postConditions(filter)
 
-	if filter.AuthorID != 0 {
-		conditions = append(conditions, fmt.Sprintf("author_id = $%d", argPosition))
-		args = append(args, filter.AuthorID)
-		argPosition++
+	sort := filter.SortField()
+	typ, ok := sortTypes[sort]
+	if !ok {
+		return nil, fmt.Errorf("unknown sort field %q", sort)
 	}
-
-	if filter.CategoryID != 0 {
-		conditions = append(conditions, fmt.Sprintf("category_id = $%d", argPosition))
-		args = append(args, filter.CategoryID)
-		argPosition++
+	direction, comparison := "DESC", "<"
+	if filter.Ascending {
+		direction, comparison = "ASC", ">"
 	}
 
-	if filter.Status != "" {
-		conditions = append(conditions, fmt.Sprintf("status = $%d", argPosition))
-		args = append(args, filter.Status)
-		argPosition++
+	query := `
+		SELECT ` + postColumns
+
+	// Search results also carry their rank and a highlighted snippet
+	searching := filter.SearchQuery != ""
+	if searching {
+		args = append(args, filter.SearchQuery, headlineOptions)
+		rank := fmt.Sprintf("ts_rank(search_vector, %s)", tsquery(len(args)-1))
+		query += ", " + rank + fmt.Sprintf(", ts_headline('%s', regexp_replace(content, '<[^>]*>', ' ', 'g'), %s, $%d)", searchConfig, tsquery(len(args)-1), len(args))
+		if sort == models.SortRelevance {
+			sort = rank
+		}
+	} else if sort == models.SortRelevance {
+		return nil, fmt.Errorf("sorting by relevance requires a search query")
 	}
 
-	if len(filter.Tags) > 0 {
-		conditions = append(conditions, fmt.Sprintf("tags && $%d", argPosition))
-		args = append(args, pq.Array(filter.Tags))
-		argPosition++
-	}
+	query += `
+		FROM posts`
 
-	if filter.SearchQuery != "" {
-		conditions = append(conditions, fmt.Sprintf("(title ILIKE $%d OR content ILIKE $%d)", argPosition, argPosition))
-		args = append(args, "%"+filter.SearchQuery+"%")
-		argPosition++
+	// Keyset pagination: only posts sorting after the cursor, so pages stay
+	// stable while posts are added and deep pages cost as much as the first
+	if filter.Cursor != nil {
+		args = append(args, filter.Cursor.Key, filter.Cursor.ID)
+		conditions = append(conditions, fmt.Sprintf("(%s, id) %s ($%d::%s, $%d)", sort, comparison, len(args)-1, typ, len(args)))
 	}
 
 	if len(conditions) > 0 {
 		query += " WHERE " + strings.Join(conditions, " AND ")
 	}
 
-	query += " ORDER BY created_at DESC"
+	query += fmt.Sprintf(" ORDER BY %s %s, id %s", sort, direction, direction)
 
 	if filter.PageSize > 0 {
-		query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", argPosition, argPosition+1)
-		args = append(args, filter.PageSize, (filter.Page-1)*filter.PageSize)
+		limit := filter.PageSize
+		if filter.Lookahead {
+			limit++
+		}
+		args = append(args, limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+
+		if filter.Cursor == nil && filter.Page > 1 {
+			args = append(args, (filter.Page-1)*filter.PageSize)
+			query += fmt.Sprintf(" OFFSET $%d", len(args))
+		}
 	}
 
 	rows, err := r.db.QueryContext(ctx, query, args...)
@@ -240,34 +299,79 @@ func (r *PostgresPostRepository) List(ctx context.Context, filter *models.PostFi
 	}
 	defer rows.Close()
 
-	var posts []*models.Post
-	for rows.Next() {
-		post := &models.Post{}
-		err := rows.Scan(
-			&post.ID,
-			&post.Title,
-			&post.Content,
-			&post.Slug,
-			&post.AuthorID,
-			&post.CategoryID,
-			&post.Status,
-			pq.Array(&post.Tags),
-			&post.ViewCount,
-			&post.PublishedAt,
-			&post.CreatedAt,
-			&post.UpdatedAt,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("error scanning post: %w", err)
+	if searching {
+		return collectSearchResults(rows)
+	}
+	return collectPosts(rows)
+}
+
+// Count returns the number of posts matching filter, ignoring pagination
+func (r *PostgresPostRepository) Count(ctx context.Context, filter *models.PostFilter) (int64, error) {
+	conditions, args := postConditions(filter)
+
+	query := "SELECT COUNT(*) FROM posts"
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var count int64
+	if err := r.db.QueryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("error counting posts: %w", err)
+	}
+
+	return count, nil
+}
+
+// postConditions translates filter into WHERE conditions and their arguments
+func postConditions(filter *models.PostFilter) ([]string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if len(filter.AuthorIDs) > 0 {
+		args = append(args, pq.Array(filter.AuthorIDs))
+		conditions = append(conditions, fmt.Sprintf("author_id = ANY($%d)", len(args)))
+	}
+
+	if len(filter.CategoryIDs) > 0 {
+		args = append(args, pq.Array(filter.CategoryIDs))
+		conditions = append(conditions, fmt.Sprintf("category_id = ANY($%d)", len(args)))
+	}
+
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+
+	if len(filter.Tags) > 0 {
+		operator := "&&"
+		if filter.AllTags {
+			operator = "@>"
 		}
-		posts = append(posts, post)
+		args = append(args, pq.Array(filter.Tags))
+		conditions = append(conditions, fmt.Sprintf("tags %s $%d", operator, len(args)))
 	}
 
-	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating posts: %w", err)
+	if !filter.PublishedAfter.IsZero() {
+		args = append(args, filter.PublishedAfter)
+		conditions = append(conditions, fmt.Sprintf("published_at >= $%d", len(args)))
 	}
 
-	return posts, nil
+	if !filter.PublishedBefore.IsZero() {
+		args = append(args, filter.PublishedBefore)
+		conditions = append(conditions, fmt.Sprintf("published_at < $%d", len(args)))
+	}
+
+	if len(filter.ExcludeIDs) > 0 {
+		args = append(args, pq.Array(filter.ExcludeIDs))
+		conditions = append(conditions, fmt.Sprintf("id <> ALL($%d)", len(args)))
+	}
+
+	if filter.SearchQuery != "" {
+		args = append(args, filter.SearchQuery)
+		conditions = append(conditions, "search_vector @@ "+tsquery(len(args)))
+	}
+
+	return conditions, args
 }
 
 func (r *PostgresPostRepository) IncrementViewCount(ctx context.Context, id int64) error {
@@ -292,3 +396,116 @@ func (r *PostgresPostRepository) IncrementViewCount(ctx context.Context, id int6
 
 	return nil
 }
+
+// ListScheduled returns scheduled posts in the order they will be published
+func (r *PostgresPostRepository) ListScheduled(ctx context.Context, limit int) ([]*models.Post, error) {
+	query := `
+		SELECT ` + postColumns + `
+		FROM posts
+		WHERE status = 'scheduled'
+		ORDER BY publish_at, id
+		LIMIT $1`
+
+	rows, err := r.db.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error listing scheduled posts: %w", err)
+	}
+	defer rows.Close()
+
+	return collectPosts(rows)
+}
+
+// PublishDue publishes up to limit scheduled posts whose publish_at has
+// passed and returns them. SKIP LOCKED lets several replicas run the
+// scheduler at once without publishing a post twice or blocking each other.
+func (r *PostgresPostRepository) PublishDue(ctx context.Context, now time.Time, limit int) ([]*models.Post, error) {
+	query := `
+		UPDATE posts
+		SET status = 'published', published_at = publish_at, publish_at = NULL, updated_at = $1
+		WHERE id IN (
+			SELECT id FROM posts
+			WHERE status = 'scheduled' AND publish_at <= $1
+			ORDER BY publish_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + postColumns
+
+	rows, err := r.db.QueryContext(ctx, query, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error publishing scheduled posts: %w", err)
+	}
+	defer rows.Close()
+
+	return collectPosts(rows)
+}
+
+func collectPosts(rows *sql.Rows) ([]*models.Post, error) {
+	var posts []*models.Post
+	for rows.Next() {
+		post, err := scanPost(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning post: %w", err)
+		}
+		posts = append(posts, post)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating posts: %w", err)
+	}
+
+	return posts, nil
+}
+
+// collectSearchResults reads posts selected with their rank and headline
+func collectSearchResults(rows *sql.Rows) ([]*models.Post, error) {
+	var posts []*models.Post
+	for rows.Next() {
+		var rank float32
+		var headline string
+		post, err := scanPost(rows, &rank, &headline)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning post: %w", err)
+		}
+		post.Rank = rank
+		post.Highlight = highlight(headline)
+		posts = append(posts, post)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating posts: %w", err)
+	}
+
+	return posts, nil
+}
+
+// scanPost reads the columns in postColumns, followed by any extra ones
+func scanPost(row scanner, extra ...interface{}) (*models.Post, error) {
+	post := &models.Post{}
+	dest := []interface{}{
+		&post.ID,
+		&post.Title,
+		&post.Content,
+		&post.ContentFormat,
+		&post.ContentHTML,
+		&post.TOC,
+		&post.Excerpt,
+		&post.ReadingTime,
+		&post.Slug,
+		&post.AuthorID,
+		&post.CategoryID,
+		&post.Status,
+		pq.Array(&post.Tags),
+		&post.ViewCount,
+		&post.PublishedAt,
+		&post.PublishAt,
+		&post.Meta.MetaTitle,
+		&post.Meta.MetaDescription,
+		&post.Meta.CanonicalURL,
+		&post.Meta.OGImageID,
+		&post.CreatedAt,
+		&post.UpdatedAt,
+	}
+	err := row.Scan(append(dest, extra...)...)
+	return post, err
+}
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/Thedrogon/blogbish/post-service/internal/models"
+	"github.com/lib/pq"
+)
+
+// RevisionRepository reads post history. Revisions are written by
+// PostRepository in the same transaction as the change they record.
+type RevisionRepository interface {
+	List(ctx context.Context, postID int64) ([]*models.PostRevision, error)
+	Get(ctx context.Context, postID int64, revision int) (*models.PostRevision, error)
+}
+
+type PostgresRevisionRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresRevisionRepository(db *sql.DB) *PostgresRevisionRepository {
+	return &PostgresRevisionRepository{db: db}
+}
+
+func (r *PostgresRevisionRepository) List(ctx context.Context, postID int64) ([]*models.PostRevision, error) {
+	query := `
+		SELECT id, post_id, revision, author_id, title, content, content_format, tags, category_id, created_at
+		FROM post_revisions
+		WHERE post_id = $1
+		ORDER BY revision DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, postID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing revisions: %w", err)
+	}
+	defer rows.Close()
+
+	var revisions []*models.PostRevision
+	for rows.Next() {
+		revision, err := scanRevision(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning revision: %w", err)
+		}
+		revisions = append(revisions, revision)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating revisions: %w", err)
+	}
+
+	return revisions, nil
+}
+
+func (r *PostgresRevisionRepository) Get(ctx context.Context, postID int64, revision int) (*models.PostRevision, error) {
+	query := `
+		SELECT id, post_id, revision, author_id, title, content, content_format, tags, category_id, created_at
+		FROM post_revisions
+		WHERE post_id = $1 AND revision = $2`
+
+	rev, err := scanRevision(r.db.QueryRowContext(ctx, query, postID, revision))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("revision not found")
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("error getting revision: %w", err)
+	}
+
+	return rev, nil
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanRevision(row scanner) (*models.PostRevision, error) {
+	revision := &models.PostRevision{}
+	err := row.Scan(
+		&revision.ID,
+		&revision.PostID,
+		&revision.Revision,
+		&revision.AuthorID,
+		&revision.Title,
+		&revision.Content,
+		&revision.ContentFormat,
+		pq.Array(&revision.Tags),
+		&revision.CategoryID,
+		&revision.CreatedAt,
+	)
+	return revision, err
+}
+
+// insertRevision snapshots post as its next revision. It runs inside the
+// transaction that changed the post, whose row lock keeps numbering gapless.
+func insertRevision(ctx context.Context, tx *sql.Tx, post *models.Post, authorID int64) error {
+	query := `
+		INSERT INTO post_revisions (post_id, revision, author_id, title, content, content_format, tags, category_id, created_at)
+		SELECT $1, COALESCE(MAX(revision), 0) + 1, $2, $3, $4, $5, $6, $7, $8
+		FROM post_revisions
+		WHERE post_id = $1`
+
+	_, err := tx.ExecContext(
+		ctx,
+		query,
+		post.ID,
+		authorID,
+		post.Title,
+		post.Content,
+		post.ContentFormat,
+		pq.Array(post.Tags),
+		post.CategoryID,
+		post.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("error recording revision: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// searchConfig is the text search configuration search_vector is built with
+const searchConfig = "english"
+
+// Matches in headlines are delimited by control characters rather than HTML,
+// so the snippet can be escaped before they are turned into <mark> elements
+const (
+	markStart = "\x02"
+	markStop  = "\x03"
+)
+
+var headlineOptions = fmt.Sprintf("StartSel=%s, StopSel=%s, MaxFragments=2, MaxWords=30, MinWords=10, FragmentDelimiter=\" … \"", markStart, markStop)
+
+// tsquery parses the search query in argument n using web search syntax:
+// "quoted phrases", or, and -excluded words
+func tsquery(n int) string {
+	return fmt.Sprintf("websearch_to_tsquery('%s', $%d)", searchConfig, n)
+}
+
+// highlight escapes a headline and wraps its matches in <mark>
+func highlight(headline string) string {
+	return strings.NewReplacer(markStart, "<mark>", markStop, "</mark>").Replace(html.EscapeString(headline))
+}
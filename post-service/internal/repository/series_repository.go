@@ -0,0 +1,435 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Thedrogon/blogbish/post-service/internal/models"
+	"github.com/lib/pq"
+)
+
+type SeriesRepository interface {
+	Create(ctx context.Context, series *models.Series, postIDs []int64) error
+	GetBySlug(ctx context.Context, slug string) (*models.Series, error)
+	List(ctx context.Context) ([]*models.Series, error)
+	Update(ctx context.Context, series *models.Series) error
+	Delete(ctx context.Context, id int64) error
+	Parts(ctx context.Context, id int64) ([]models.SeriesPart, error)
+	Navigation(ctx context.Context, postID int64) (*models.SeriesNav, error)
+	AddPost(ctx context.Context, id, postID int64, part int) error
+	RemovePost(ctx context.Context, id, postID int64) error
+	Reorder(ctx context.Context, id int64, postIDs []int64) error
+}
+
+// seriesColumns lists the columns read by scanSeries, in order. Series are
+// selected as s so the part count can refer to them.
+const seriesColumns = "s.id, s.title, s.slug, s.description, s.author_id, s.created_at, s.updated_at, " +
+	"(SELECT COUNT(*) FROM series_posts WHERE series_id = s.id)"
+
+type PostgresSeriesRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresSeriesRepository(db *sql.DB) *PostgresSeriesRepository {
+	return &PostgresSeriesRepository{db: db}
+}
+
+// Create adds a series with postIDs as its parts, in order
+func (r *PostgresSeriesRepository) Create(ctx context.Context, series *models.Series, postIDs []int64) error {
+	query := `
+		INSERT INTO series (title, slug, description, author_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id`
+
+	now := time.Now()
+	series.CreatedAt = now
+	series.UpdatedAt = now
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(
+		ctx,
+		query,
+		series.Title,
+		series.Slug,
+		series.Description,
+		series.AuthorID,
+		series.CreatedAt,
+		series.UpdatedAt,
+	).Scan(&series.ID)
+	if err != nil {
+		return fmt.Errorf("error creating series: %w", err)
+	}
+
+	if err := writeParts(ctx, tx, series.ID, postIDs); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing series: %w", err)
+	}
+
+	series.Parts = len(postIDs)
+	return nil
+}
+
+// GetBySlug finds a series by its current slug or a former one
+func (r *PostgresSeriesRepository) GetBySlug(ctx context.Context, slug string) (*models.Series, error) {
+	query := `SELECT ` + seriesColumns + ` FROM series s ` + seriesSlugs.bySlug("$1")
+
+	series, err := scanSeries(r.db.QueryRowContext(ctx, query, slug))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("series not found")
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("error getting series: %w", err)
+	}
+
+	return series, nil
+}
+
+// List returns every series, newest first
+func (r *PostgresSeriesRepository) List(ctx context.Context) ([]*models.Series, error) {
+	query := `SELECT ` + seriesColumns + ` FROM series s ORDER BY s.created_at DESC, s.id DESC`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("error listing series: %w", err)
+	}
+	defer rows.Close()
+
+	var list []*models.Series
+	for rows.Next() {
+		series, err := scanSeries(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning series: %w", err)
+		}
+		list = append(list, series)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating series: %w", err)
+	}
+
+	return list, nil
+}
+
+func (r *PostgresSeriesRepository) Update(ctx context.Context, series *models.Series) error {
+	query := `
+		UPDATE series
+		SET title = $1, slug = $2, description = $3, updated_at = $4
+		WHERE id = $5`
+
+	series.UpdatedAt = time.Now()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := seriesSlugs.rename(ctx, tx, series.ID, series.Slug); err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, query, series.Title, series.Slug, series.Description, series.UpdatedAt, series.ID)
+	if err != nil {
+		return fmt.Errorf("error updating series: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error getting rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("series not found")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing series: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a series; its posts are kept
+func (r *PostgresSeriesRepository) Delete(ctx context.Context, id int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM series WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("error deleting series: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error getting rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("series not found")
+	}
+
+	return nil
+}
+
+// Parts returns every post of a series in order, published or not
+func (r *PostgresSeriesRepository) Parts(ctx context.Context, id int64) ([]models.SeriesPart, error) {
+	query := `
+		SELECT p.id, p.title, p.slug, p.status, ROW_NUMBER() OVER (ORDER BY sp.position)
+		FROM series_posts sp
+		JOIN posts p ON p.id = sp.post_id
+		WHERE sp.series_id = $1
+		ORDER BY sp.position`
+
+	rows, err := r.db.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("error listing series parts: %w", err)
+	}
+	defer rows.Close()
+
+	var parts []models.SeriesPart
+	for rows.Next() {
+		var part models.SeriesPart
+		if err := rows.Scan(&part.ID, &part.Title, &part.Slug, &part.Status, &part.Part); err != nil {
+			return nil, fmt.Errorf("error scanning series part: %w", err)
+		}
+		parts = append(parts, part)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating series parts: %w", err)
+	}
+
+	return parts, nil
+}
+
+// Navigation places a post within its series, linking the nearest published
+// parts around it. It returns nil for posts that are not part of a series.
+func (r *PostgresSeriesRepository) Navigation(ctx context.Context, postID int64) (*models.SeriesNav, error) {
+	query := `
+		WITH parts AS (
+			SELECT sp.series_id, p.id, p.title, p.slug, p.status,
+				ROW_NUMBER() OVER (ORDER BY sp.position) AS part
+			FROM series_posts sp
+			JOIN posts p ON p.id = sp.post_id
+			WHERE sp.series_id = (SELECT series_id FROM series_posts WHERE post_id = $1)
+		)
+		SELECT s.id, s.title, s.slug, cur.part, (SELECT COUNT(*) FROM parts),
+			prev_part.id, prev_part.title, prev_part.slug, prev_part.part,
+			next_part.id, next_part.title, next_part.slug, next_part.part
+		FROM parts cur
+		JOIN series s ON s.id = cur.series_id
+		LEFT JOIN LATERAL (
+			SELECT * FROM parts
+			WHERE part < cur.part AND status = 'published'
+			ORDER BY part DESC
+			LIMIT 1
+		) prev_part ON true
+		LEFT JOIN LATERAL (
+			SELECT * FROM parts
+			WHERE part > cur.part AND status = 'published'
+			ORDER BY part ASC
+			LIMIT 1
+		) next_part ON true
+		WHERE cur.id = $1`
+
+	var nav models.SeriesNav
+	var prev, next nullPart
+	err := r.db.QueryRowContext(ctx, query, postID).Scan(
+		&nav.ID, &nav.Title, &nav.Slug, &nav.Part, &nav.Parts,
+		&prev.ID, &prev.Title, &prev.Slug, &prev.Part,
+		&next.ID, &next.Title, &next.Slug, &next.Part,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("error getting series navigation: %w", err)
+	}
+
+	nav.Previous = prev.part()
+	nav.Next = next.part()
+	return &nav, nil
+}
+
+// AddPost makes a post part number part of a series, moving later parts
+// back, or the last part when part is zero or beyond the end
+func (r *PostgresSeriesRepository) AddPost(ctx context.Context, id, postID int64, part int) error {
+	return r.editParts(ctx, id, func(postIDs []int64) ([]int64, error) {
+		if slices.Contains(postIDs, postID) {
+			return nil, fmt.Errorf("post is already part of the series")
+		}
+		if part < 1 || part > len(postIDs) {
+			return append(postIDs, postID), nil
+		}
+		return slices.Insert(postIDs, part-1, postID), nil
+	})
+}
+
+// RemovePost takes a post out of a series; later parts move up
+func (r *PostgresSeriesRepository) RemovePost(ctx context.Context, id, postID int64) error {
+	return r.editParts(ctx, id, func(postIDs []int64) ([]int64, error) {
+		i := slices.Index(postIDs, postID)
+		if i < 0 {
+			return nil, fmt.Errorf("post is not part of the series")
+		}
+		return slices.Delete(postIDs, i, i+1), nil
+	})
+}
+
+// Reorder puts the posts of a series in the order of postIDs, which must list
+// each of them once
+func (r *PostgresSeriesRepository) Reorder(ctx context.Context, id int64, postIDs []int64) error {
+	return r.editParts(ctx, id, func(current []int64) ([]int64, error) {
+		if !samePosts(current, postIDs) {
+			return nil, fmt.Errorf("reordered posts do not match the series")
+		}
+		return postIDs, nil
+	})
+}
+
+// editParts rewrites the parts of a series with the order edit derives from
+// the current one. The series is locked meanwhile, so concurrent edits apply
+// one after the other rather than overwrite each other.
+func (r *PostgresSeriesRepository) editParts(ctx context.Context, id int64, edit func(postIDs []int64) ([]int64, error)) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var locked int64
+	err = tx.QueryRowContext(ctx, `SELECT id FROM series WHERE id = $1 FOR UPDATE`, id).Scan(&locked)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("series not found")
+	}
+	if err != nil {
+		return fmt.Errorf("error locking series: %w", err)
+	}
+
+	rows, err := tx.QueryContext(ctx, `SELECT post_id FROM series_posts WHERE series_id = $1 ORDER BY position`, id)
+	if err != nil {
+		return fmt.Errorf("error listing series parts: %w", err)
+	}
+	var postIDs []int64
+	for rows.Next() {
+		var postID int64
+		if err := rows.Scan(&postID); err != nil {
+			rows.Close()
+			return fmt.Errorf("error scanning series part: %w", err)
+		}
+		postIDs = append(postIDs, postID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating series parts: %w", err)
+	}
+
+	postIDs, err = edit(postIDs)
+	if err != nil {
+		return err
+	}
+
+	if err := writeParts(ctx, tx, id, postIDs); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE series SET updated_at = $1 WHERE id = $2`, time.Now(), id); err != nil {
+		return fmt.Errorf("error updating series: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing series parts: %w", err)
+	}
+
+	return nil
+}
+
+// writeParts makes postIDs the parts of series id, in order
+func writeParts(ctx context.Context, tx *sql.Tx, id int64, postIDs []int64) error {
+	if postIDs == nil {
+		postIDs = []int64{} // a NULL array would match nothing
+	}
+
+	remove := `DELETE FROM series_posts WHERE series_id = $1 AND post_id <> ALL($2)`
+	if _, err := tx.ExecContext(ctx, remove, id, pq.Array(postIDs)); err != nil {
+		return fmt.Errorf("error removing series parts: %w", err)
+	}
+
+	// Positions may clash until every row is written; the constraint is
+	// checked at commit
+	upsert := `
+		INSERT INTO series_posts (series_id, post_id, position)
+		SELECT $1, parts.post_id, parts.position
+		FROM unnest($2::bigint[]) WITH ORDINALITY AS parts(post_id, position)
+		ON CONFLICT (series_id, post_id) DO UPDATE SET position = EXCLUDED.position`
+	if _, err := tx.ExecContext(ctx, upsert, id, pq.Array(postIDs)); err != nil {
+		return fmt.Errorf("error writing series parts: %w", err)
+	}
+
+	return nil
+}
+
+// samePosts reports whether a and b hold the same post IDs, each once
+func samePosts(a, b []int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	seen := make(map[int64]bool, len(a))
+	for _, id := range a {
+		seen[id] = true
+	}
+	for _, id := range b {
+		if !seen[id] {
+			return false
+		}
+		delete(seen, id)
+	}
+	return true
+}
+
+// nullPart scans a part that may be missing from a LEFT JOIN
+type nullPart struct {
+	ID    sql.NullInt64
+	Title sql.NullString
+	Slug  sql.NullString
+	Part  sql.NullInt64
+}
+
+func (p nullPart) part() *models.SeriesPart {
+	if !p.ID.Valid {
+		return nil
+	}
+	return &models.SeriesPart{
+		ID:     p.ID.Int64,
+		Title:  p.Title.String,
+		Slug:   p.Slug.String,
+		Part:   int(p.Part.Int64),
+		Status: "published",
+	}
+}
+
+// scanSeries reads seriesColumns
+func scanSeries(row scanner) (*models.Series, error) {
+	series := &models.Series{}
+	err := row.Scan(
+		&series.ID,
+		&series.Title,
+		&series.Slug,
+		&series.Description,
+		&series.AuthorID,
+		&series.CreatedAt,
+		&series.UpdatedAt,
+		&series.Parts,
+	)
+	return series, err
+}
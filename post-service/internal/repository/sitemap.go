@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/Thedrogon/blogbish/post-service/internal/models"
+)
+
+// Sitemaps split resources by ID range: page n holds IDs
+// ((n-1)*size, n*size]. See package sitemap.
+
+func (r *PostgresPostRepository) SitemapPages(ctx context.Context, size int) ([]models.SitemapPage, error) {
+	query := `
+		SELECT (id - 1) / $1 + 1 AS page, MAX(updated_at)
+		FROM posts
+		WHERE status = 'published'
+		GROUP BY page
+		ORDER BY page`
+
+	return sitemapPages(ctx, r.db, query, size)
+}
+
+func (r *PostgresPostRepository) SitemapEntries(ctx context.Context, page, size int) ([]models.SitemapEntry, error) {
+	query := `
+		SELECT slug, updated_at
+		FROM posts
+		WHERE status = 'published' AND id > $1 AND id <= $2
+		ORDER BY id`
+
+	return sitemapEntries(ctx, r.db, query, page, size)
+}
+
+func (r *PostgresCategoryRepository) SitemapPages(ctx context.Context, size int) ([]models.SitemapPage, error) {
+	query := `
+		SELECT (id - 1) / $1 + 1 AS page, MAX(updated_at)
+		FROM categories
+		GROUP BY page
+		ORDER BY page`
+
+	return sitemapPages(ctx, r.db, query, size)
+}
+
+func (r *PostgresCategoryRepository) SitemapEntries(ctx context.Context, page, size int) ([]models.SitemapEntry, error) {
+	query := `
+		SELECT slug, updated_at
+		FROM categories
+		WHERE id > $1 AND id <= $2
+		ORDER BY id`
+
+	return sitemapEntries(ctx, r.db, query, page, size)
+}
+
+func sitemapPages(ctx context.Context, db *sql.DB, query string, size int) ([]models.SitemapPage, error) {
+	rows, err := db.QueryContext(ctx, query, size)
+	if err != nil {
+		return nil, fmt.Errorf("error listing sitemap pages: %w", err)
+	}
+	defer rows.Close()
+
+	var pages []models.SitemapPage
+	for rows.Next() {
+		var page models.SitemapPage
+		if err := rows.Scan(&page.Page, &page.LastModified); err != nil {
+			return nil, fmt.Errorf("error scanning sitemap page: %w", err)
+		}
+		pages = append(pages, page)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating sitemap pages: %w", err)
+	}
+
+	return pages, nil
+}
+
+func sitemapEntries(ctx context.Context, db *sql.DB, query string, page, size int) ([]models.SitemapEntry, error) {
+	first := int64(page-1) * int64(size)
+	rows, err := db.QueryContext(ctx, query, first, first+int64(size))
+	if err != nil {
+		return nil, fmt.Errorf("error listing sitemap entries: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []models.SitemapEntry
+	for rows.Next() {
+		var entry models.SitemapEntry
+		if err := rows.Scan(&entry.Slug, &entry.LastModified); err != nil {
+			return nil, fmt.Errorf("error scanning sitemap entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating sitemap entries: %w", err)
+	}
+
+	return entries, nil
+}
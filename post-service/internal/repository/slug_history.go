@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// slugHistory describes the table recording the former slugs of a resource
+type slugHistory struct {
+	table   string // e.g. posts
+	history string // e.g. post_slugs
+	column  string // the history's foreign key, e.g. post_id
+}
+
+var (
+	postSlugs     = slugHistory{table: "posts", history: "post_slugs", column: "post_id"}
+	categorySlugs = slugHistory{table: "categories", history: "category_slugs", column: "category_id"}
+	seriesSlugs   = slugHistory{table: "series", history: "series_slugs", column: "series_id"}
+
+	// A tag's former slugs are aliases like any other
+	tagSlugs = slugHistory{table: "tags", history: "tag_aliases", column: "tag_id"}
+)
+
+// bySlug matches rows by current slug or, failing that, by a former one.
+// The current slug wins should both match.
+func (h slugHistory) bySlug(param string) string {
+	return fmt.Sprintf(`WHERE slug = %[1]s OR id = (SELECT %[2]s FROM %[3]s WHERE slug = %[1]s)
+		ORDER BY slug = %[1]s DESC
+		LIMIT 1`, param, h.column, h.history)
+}
+
+// rename must run in the transaction that changes the slug of row id to
+// slug, before the update. It remembers the current slug, and forgets slug
+// since it is about to be current again.
+func (h slugHistory) rename(ctx context.Context, tx *sql.Tx, id int64, slug string) error {
+	record := fmt.Sprintf(`
+		INSERT INTO %[1]s (slug, %[2]s)
+		SELECT slug, id FROM %[3]s WHERE id = $1 AND slug <> $2
+		ON CONFLICT (slug) DO UPDATE SET %[2]s = EXCLUDED.%[2]s, created_at = CURRENT_TIMESTAMP`,
+		h.history, h.column, h.table)
+	if _, err := tx.ExecContext(ctx, record, id, slug); err != nil {
+		return fmt.Errorf("error recording former slug: %w", err)
+	}
+
+	forget := fmt.Sprintf(`DELETE FROM %s WHERE slug = $1`, h.history)
+	if _, err := tx.ExecContext(ctx, forget, slug); err != nil {
+		return fmt.Errorf("error updating slug history: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,299 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Thedrogon/blogbish/post-service/internal/models"
+)
+
+type TagRepository interface {
+	GetBySlug(ctx context.Context, slug string) (*models.Tag, error)
+	List(ctx context.Context) ([]*models.Tag, error)
+	Popular(ctx context.Context, limit int) ([]*models.Tag, error)
+	Resolve(ctx context.Context, tags []*models.Tag) ([]string, error)
+	Update(ctx context.Context, tag *models.Tag) error
+	Aliases(ctx context.Context, id int64) ([]string, error)
+	AddAlias(ctx context.Context, id int64, alias string) error
+	RemoveAlias(ctx context.Context, id int64, alias string) error
+	Merge(ctx context.Context, from, into *models.Tag) error
+}
+
+// tagColumns lists the columns read by scanTag, in order. Tags are selected
+// as t so the post count can refer to them.
+const tagColumns = "t.id, t.name, t.slug, t.description, t.created_at, t.updated_at"
+
+// tagPostCounts counts the published posts carrying each tag
+const tagPostCounts = `
+	SELECT tag, COUNT(*) AS post_count
+	FROM posts, unnest(posts.tags) AS tag
+	WHERE posts.status = 'published'
+	GROUP BY tag`
+
+type PostgresTagRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresTagRepository(db *sql.DB) *PostgresTagRepository {
+	return &PostgresTagRepository{db: db}
+}
+
+// GetBySlug finds a tag by its slug or one of its aliases
+func (r *PostgresTagRepository) GetBySlug(ctx context.Context, slug string) (*models.Tag, error) {
+	query := `
+		SELECT ` + tagColumns + `,
+			(SELECT COUNT(*) FROM posts WHERE posts.status = 'published' AND posts.tags @> ARRAY[t.slug::text])
+		FROM tags t
+		` + tagSlugs.bySlug("$1")
+
+	tag, err := scanTag(r.db.QueryRowContext(ctx, query, slug))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("tag not found")
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("error getting tag: %w", err)
+	}
+
+	return tag, nil
+}
+
+// List returns every tag by name, including unused ones
+func (r *PostgresTagRepository) List(ctx context.Context) ([]*models.Tag, error) {
+	query := `
+		SELECT ` + tagColumns + `, COALESCE(counts.post_count, 0)
+		FROM tags t
+		LEFT JOIN (` + tagPostCounts + `) counts ON counts.tag = t.slug
+		ORDER BY t.name ASC`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("error listing tags: %w", err)
+	}
+	defer rows.Close()
+
+	return collectTags(rows)
+}
+
+// Popular returns up to limit tags of published posts, most used first
+func (r *PostgresTagRepository) Popular(ctx context.Context, limit int) ([]*models.Tag, error) {
+	query := `
+		SELECT ` + tagColumns + `, counts.post_count
+		FROM tags t
+		JOIN (` + tagPostCounts + `) counts ON counts.tag = t.slug
+		ORDER BY counts.post_count DESC, t.name ASC
+		LIMIT $1`
+
+	rows, err := r.db.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error listing popular tags: %w", err)
+	}
+	defer rows.Close()
+
+	return collectTags(rows)
+}
+
+// Resolve returns the slugs of the canonical tags for tags, which need only a
+// name and slug. Aliases resolve to their tag, and tags seen for the first
+// time are created.
+func (r *PostgresTagRepository) Resolve(ctx context.Context, tags []*models.Tag) ([]string, error) {
+	lookup := `SELECT t.slug FROM tags t ` + tagSlugs.bySlug("$1")
+	create := `
+		INSERT INTO tags (name, slug, created_at, updated_at)
+		VALUES ($1, $2, $3, $3)
+		ON CONFLICT (slug) DO NOTHING`
+
+	slugs := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		var slug string
+		err := r.db.QueryRowContext(ctx, lookup, tag.Slug).Scan(&slug)
+		switch {
+		case err == sql.ErrNoRows:
+			if _, err := r.db.ExecContext(ctx, create, tag.Name, tag.Slug, time.Now()); err != nil {
+				return nil, fmt.Errorf("error creating tag: %w", err)
+			}
+			slug = tag.Slug
+		case err != nil:
+			return nil, fmt.Errorf("error resolving tag: %w", err)
+		}
+		slugs = append(slugs, slug)
+	}
+
+	return slugs, nil
+}
+
+// Update saves a tag. When its slug changes, the old one becomes an alias and
+// posts are retagged.
+func (r *PostgresTagRepository) Update(ctx context.Context, tag *models.Tag) error {
+	tag.UpdatedAt = time.Now()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var oldSlug string
+	err = tx.QueryRowContext(ctx, `SELECT slug FROM tags WHERE id = $1 FOR UPDATE`, tag.ID).Scan(&oldSlug)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("tag not found")
+	}
+	if err != nil {
+		return fmt.Errorf("error getting tag: %w", err)
+	}
+
+	if err := tagSlugs.rename(ctx, tx, tag.ID, tag.Slug); err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE tags
+		SET name = $1, slug = $2, description = $3, updated_at = $4
+		WHERE id = $5`
+	if _, err := tx.ExecContext(ctx, query, tag.Name, tag.Slug, tag.Description, tag.UpdatedAt, tag.ID); err != nil {
+		return fmt.Errorf("error updating tag: %w", err)
+	}
+
+	if oldSlug != tag.Slug {
+		retag := `
+			UPDATE posts
+			SET tags = array_replace(tags, $1::text, $2::text)
+			WHERE tags @> ARRAY[$1::text]`
+		if _, err := tx.ExecContext(ctx, retag, oldSlug, tag.Slug); err != nil {
+			return fmt.Errorf("error retagging posts: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing tag: %w", err)
+	}
+
+	return nil
+}
+
+// Aliases returns the aliases of the tag with id
+func (r *PostgresTagRepository) Aliases(ctx context.Context, id int64) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT slug FROM tag_aliases WHERE tag_id = $1 ORDER BY slug`, id)
+	if err != nil {
+		return nil, fmt.Errorf("error listing tag aliases: %w", err)
+	}
+	defer rows.Close()
+
+	aliases := []string{}
+	for rows.Next() {
+		var alias string
+		if err := rows.Scan(&alias); err != nil {
+			return nil, fmt.Errorf("error scanning tag alias: %w", err)
+		}
+		aliases = append(aliases, alias)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating tag aliases: %w", err)
+	}
+
+	return aliases, nil
+}
+
+// AddAlias makes alias, a slug no tag uses, resolve to the tag with id
+func (r *PostgresTagRepository) AddAlias(ctx context.Context, id int64, alias string) error {
+	query := `INSERT INTO tag_aliases (slug, tag_id) VALUES ($1, $2)`
+	if _, err := r.db.ExecContext(ctx, query, alias, id); err != nil {
+		return fmt.Errorf("error adding tag alias: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresTagRepository) RemoveAlias(ctx context.Context, id int64, alias string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM tag_aliases WHERE slug = $1 AND tag_id = $2`, alias, id)
+	if err != nil {
+		return fmt.Errorf("error removing tag alias: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error getting rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("tag alias not found")
+	}
+
+	return nil
+}
+
+// Merge folds from into another tag: posts are retagged, and from's slug and
+// aliases become aliases of into before from is deleted
+func (r *PostgresTagRepository) Merge(ctx context.Context, from, into *models.Tag) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Posts carrying both tags keep a single one
+	retag := `
+		UPDATE posts
+		SET tags = CASE WHEN tags @> ARRAY[$2::text]
+			THEN array_remove(tags, $1::text)
+			ELSE array_replace(tags, $1::text, $2::text) END
+		WHERE tags @> ARRAY[$1::text]`
+	if _, err := tx.ExecContext(ctx, retag, from.Slug, into.Slug); err != nil {
+		return fmt.Errorf("error retagging posts: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE tag_aliases SET tag_id = $1 WHERE tag_id = $2`, into.ID, from.ID); err != nil {
+		return fmt.Errorf("error moving tag aliases: %w", err)
+	}
+
+	alias := `
+		INSERT INTO tag_aliases (slug, tag_id) VALUES ($1, $2)
+		ON CONFLICT (slug) DO UPDATE SET tag_id = EXCLUDED.tag_id`
+	if _, err := tx.ExecContext(ctx, alias, from.Slug, into.ID); err != nil {
+		return fmt.Errorf("error adding tag alias: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM tags WHERE id = $1`, from.ID); err != nil {
+		return fmt.Errorf("error deleting tag: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing tag merge: %w", err)
+	}
+
+	return nil
+}
+
+func collectTags(rows *sql.Rows) ([]*models.Tag, error) {
+	var tags []*models.Tag
+	for rows.Next() {
+		tag, err := scanTag(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning tag: %w", err)
+		}
+		tags = append(tags, tag)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating tags: %w", err)
+	}
+
+	return tags, nil
+}
+
+// scanTag reads tagColumns followed by the post count
+func scanTag(row scanner) (*models.Tag, error) {
+	tag := &models.Tag{}
+	err := row.Scan(
+		&tag.ID,
+		&tag.Name,
+		&tag.Slug,
+		&tag.Description,
+		&tag.CreatedAt,
+		&tag.UpdatedAt,
+		&tag.PostCount,
+	)
+	return tag, err
+}
@@ -0,0 +1,91 @@
+// Package scheduler publishes scheduled posts once their publish_at passes
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Thedrogon/blogbish/Internals/metrics"
+	"github.com/Thedrogon/blogbish/post-service/internal/cache"
+	"github.com/Thedrogon/blogbish/post-service/internal/repository"
+	"github.com/Thedrogon/blogbish/post-service/internal/sitemap"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// batchSize is the number of posts published per database round trip
+const batchSize = 100
+
+var published = promauto.NewCounter(prometheus.CounterOpts{
+	Namespace: metrics.Namespace,
+	Name:      "scheduled_posts_published_total",
+	Help:      "Total number of scheduled posts published by the scheduler.",
+})
+
+// Scheduler polls for due posts. Every replica can run one: the repository
+// claims posts with row locks, so each post is published exactly once.
+type Scheduler struct {
+	posts    repository.PostRepository
+	cache    cache.Cache
+	interval time.Duration
+	logger   *slog.Logger
+}
+
+func New(posts repository.PostRepository, cache cache.Cache, interval time.Duration, logger *slog.Logger) *Scheduler {
+	return &Scheduler{
+		posts:    posts,
+		cache:    cache,
+		interval: interval,
+		logger:   logger.With("component", "scheduler"),
+	}
+}
+
+// Run publishes due posts every interval until ctx is cancelled
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.publishDue(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Scheduler) publishDue(ctx context.Context) {
+	for {
+		posts, err := s.posts.PublishDue(ctx, time.Now(), batchSize)
+		if err != nil {
+			if ctx.Err() == nil {
+				s.logger.Error("error publishing scheduled posts", "error", err)
+			}
+			return
+		}
+
+		for _, post := range posts {
+			// Drop the cached scheduled copy so readers see the published post
+			if err := s.cache.DeletePost(ctx, post.Slug); err != nil {
+				s.logger.Warn("error invalidating cached post", "slug", post.Slug, "error", err)
+			}
+			if err := s.cache.DeleteSitemaps(ctx, sitemap.Index, sitemap.Name(sitemap.Posts, sitemap.Page(post.ID))); err != nil {
+				s.logger.Warn("error invalidating cached sitemap", "post_id", post.ID, "error", err)
+			}
+			s.logger.Info("published scheduled post", "post_id", post.ID, "slug", post.Slug)
+		}
+		if len(posts) > 0 {
+			if err := s.cache.DeleteFeeds(ctx); err != nil {
+				s.logger.Warn("error invalidating cached feeds", "error", err)
+			}
+		}
+		published.Add(float64(len(posts)))
+
+		if len(posts) < batchSize {
+			return
+		}
+	}
+}
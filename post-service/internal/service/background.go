@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+	"sync"
+)
+
+// background runs writes that outlive the request that triggered them, such
+// as cache updates, and tracks them so they can be flushed on shutdown
+type background struct {
+	pending sync.WaitGroup
+}
+
+// Go runs fn in the background with a context detached from the request
+func (b *background) Go(fn func(ctx context.Context)) {
+	b.pending.Add(1)
+	go func() {
+		defer b.pending.Done()
+		fn(context.Background())
+	}()
+}
+
+// Flush waits for background writes to complete or for ctx to expire
+func (b *background) Flush(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		b.pending.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -2,26 +2,42 @@ package service
 
 import (
 	"context"
+	"errors"
+	"slices"
 
 	"github.com/Thedrogon/blogbish/post-service/internal/cache"
 	"github.com/Thedrogon/blogbish/post-service/internal/models"
 	"github.com/Thedrogon/blogbish/post-service/internal/repository"
+	"github.com/Thedrogon/blogbish/post-service/internal/sitemap"
 	"github.com/Thedrogon/blogbish/post-service/internal/utils"
 )
 
 type CategoryService struct {
 	categoryRepo repository.CategoryRepository
 	cache        cache.Cache
+	background   *background
 }
 
 func NewCategoryService(categoryRepo repository.CategoryRepository, cache cache.Cache) *CategoryService {
 	return &CategoryService{
 		categoryRepo: categoryRepo,
 		cache:        cache,
+		background:   &background{},
 	}
 }
 
+// Flush waits for background cache writes to complete or for ctx to expire
+func (s *CategoryService) Flush(ctx context.Context) error {
+	return s.background.Flush(ctx)
+}
+
 func (s *CategoryService) CreateCategory(ctx context.Context, input *models.CategoryCreate) (*models.CategoryResponse, error) {
+	if input.ParentID != nil {
+		if _, err := s.categoryRepo.GetByID(ctx, *input.ParentID); err != nil {
+			return nil, ErrCategoryNotFound
+		}
+	}
+
 	// Generate slug from name
 	slug := utils.GenerateUniqueSlug(input.Name, func(slug string) bool {
 		_, err := s.categoryRepo.GetBySlug(ctx, slug)
@@ -32,6 +48,7 @@ func (s *CategoryService) CreateCategory(ctx context.Context, input *models.Cate
 		Name:        input.Name,
 		Slug:        slug,
 		Description: input.Description,
+		ParentID:    input.ParentID,
 	}
 
 	if err := s.categoryRepo.Create(ctx, category); err != nil {
@@ -39,18 +56,44 @@ func (s *CategoryService) CreateCategory(ctx context.Context, input *models.Cate
 	}
 
 	// Cache the new category
-	go func() {
-		ctx := context.Background()
+	s.background.Go(func(ctx context.Context) {
 		_ = s.cache.SetCategory(ctx, category)
-	}()
+		s.invalidateSitemaps(ctx, category.ID)
+	})
 
 	return category.ToResponse(), nil
 }
 
+// GetCategory returns a category with its breadcrumb path
 func (s *CategoryService) GetCategory(ctx context.Context, slug string) (*models.CategoryResponse, error) {
+	category, err := s.getCategory(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.withPath(ctx, category)
+}
+
+// withPath converts category to a response carrying its breadcrumb path
+func (s *CategoryService) withPath(ctx context.Context, category *models.Category) (*models.CategoryResponse, error) {
+	// Paths change whenever an ancestor is renamed or moved, so they are
+	// looked up rather than cached
+	ancestors, err := s.categoryRepo.Ancestors(ctx, category.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := category.ToResponse()
+	for _, ancestor := range ancestors {
+		response.Path = append(response.Path, ancestor.Breadcrumb())
+	}
+	return response, nil
+}
+
+func (s *CategoryService) getCategory(ctx context.Context, slug string) (*models.Category, error) {
 	// Try to get from cache first
 	if category, err := s.cache.GetCategory(ctx, slug); err == nil && category != nil {
-		return category.ToResponse(), nil
+		return category, nil
 	}
 
 	// If not in cache, get from database
@@ -60,12 +103,11 @@ func (s *CategoryService) GetCategory(ctx context.Context, slug string) (*models
 	}
 
 	// Cache the category
-	go func() {
-		ctx := context.Background()
+	s.background.Go(func(ctx context.Context) {
 		_ = s.cache.SetCategory(ctx, category)
-	}()
+	})
 
-	return category.ToResponse(), nil
+	return category, nil
 }
 
 func (s *CategoryService) UpdateCategory(ctx context.Context, slug string, input *models.CategoryUpdate) (*models.CategoryResponse, error) {
@@ -80,11 +122,9 @@ func (s *CategoryService) UpdateCategory(ctx context.Context, slug string, input
 		category.Name = input.Name
 		// Generate new slug only if name changes
 		newSlug := utils.GenerateUniqueSlug(input.Name, func(slug string) bool {
-			if slug == category.Slug {
-				return false
-			}
-			_, err := s.categoryRepo.GetBySlug(ctx, slug)
-			return err == nil
+			// A category may take back one of its own former slugs
+			other, err := s.categoryRepo.GetBySlug(ctx, slug)
+			return err == nil && other.ID != category.ID
 		})
 		category.Slug = newSlug
 	}
@@ -98,14 +138,16 @@ func (s *CategoryService) UpdateCategory(ctx context.Context, slug string, input
 	}
 
 	// Update cache
-	go func() {
-		ctx := context.Background()
+	s.background.Go(func(ctx context.Context) {
 		// Delete old cache entry if slug changed
 		if oldSlug != category.Slug {
 			_ = s.cache.DeleteCategory(ctx, oldSlug)
 		}
 		_ = s.cache.SetCategory(ctx, category)
-	}()
+		// Category feeds are titled after the category
+		_ = s.cache.DeleteFeeds(ctx)
+		s.invalidateSitemaps(ctx, category.ID)
+	})
 
 	return category.ToResponse(), nil
 }
@@ -116,20 +158,83 @@ func (s *CategoryService) DeleteCategory(ctx context.Context, slug string) error
 		return ErrNotFound
 	}
 
+	// Children have to be moved or deleted first
+	subtree, err := s.categoryRepo.Descendants(ctx, category.ID)
+	if err != nil {
+		return err
+	}
+	if len(subtree) > 1 {
+		return ErrInvalidOperation
+	}
+
 	if err := s.categoryRepo.Delete(ctx, category.ID); err != nil {
 		return err
 	}
 
 	// Delete from cache
-	go func() {
-		ctx := context.Background()
-		_ = s.cache.DeleteCategory(ctx, slug)
-	}()
+	s.background.Go(func(ctx context.Context) {
+		_ = s.cache.DeleteCategory(ctx, category.Slug)
+		s.invalidateSitemaps(ctx, category.ID)
+	})
 
 	return nil
 }
 
-func (s *CategoryService) ListCategories(ctx context.Context) ([]*models.CategoryResponse, error) {
+// MoveCategory moves a category and its subtree under another parent, or to
+// the root. A category cannot be moved below itself.
+func (s *CategoryService) MoveCategory(ctx context.Context, slug string, input *models.CategoryMove) (*models.CategoryResponse, error) {
+	category, err := s.categoryRepo.GetBySlug(ctx, slug)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	if input.ParentID != nil {
+		if _, err := s.categoryRepo.GetByID(ctx, *input.ParentID); err != nil {
+			return nil, ErrCategoryNotFound
+		}
+
+		subtree, err := s.categoryRepo.Descendants(ctx, category.ID)
+		if err != nil {
+			return nil, err
+		}
+		if slices.Contains(subtree, *input.ParentID) {
+			return nil, ErrInvalidOperation
+		}
+	}
+
+	// The check above can race a concurrent move; the repository repeats it
+	// under a lock
+	if err := s.categoryRepo.Move(ctx, category.ID, input.ParentID); err != nil {
+		if errors.Is(err, repository.ErrCategoryCycle) {
+			return nil, ErrInvalidOperation
+		}
+		return nil, err
+	}
+
+	s.background.Go(func(ctx context.Context) {
+		_ = s.cache.DeleteCategory(ctx, category.Slug)
+		// Feeds of the old and new ancestors include the subtree's posts
+		_ = s.cache.DeleteFeeds(ctx)
+	})
+
+	// Built from the database, as the cached copy may still predate the move
+	moved, err := s.categoryRepo.GetByID(ctx, category.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.withPath(ctx, moved)
+}
+
+// invalidateSitemaps drops the cached sitemap listing the category with id
+// and the index that dates it
+func (s *CategoryService) invalidateSitemaps(ctx context.Context, id int64) {
+	_ = s.cache.DeleteSitemaps(ctx, sitemap.Index, sitemap.Name(sitemap.Categories, sitemap.Page(id)))
+}
+
+// ListCategories returns every category by name or, with tree set, the root
+// categories with their descendants nested under them
+func (s *CategoryService) ListCategories(ctx context.Context, tree bool) ([]*models.CategoryResponse, error) {
 	categories, err := s.categoryRepo.List(ctx)
 	if err != nil {
 		return nil, err
@@ -141,5 +246,36 @@ func (s *CategoryService) ListCategories(ctx context.Context) ([]*models.Categor
 		responses[i] = category.ToResponse()
 	}
 
+	if tree {
+		return categoryTree(responses), nil
+	}
 	return responses, nil
 }
+
+// categoryTree nests categories under their parents, keeping their order
+// among siblings, and returns the roots
+func categoryTree(categories []*models.CategoryResponse) []*models.CategoryResponse {
+	byID := make(map[int64]*models.CategoryResponse, len(categories))
+	for _, category := range categories {
+		byID[category.ID] = category
+	}
+
+	roots := make([]*models.CategoryResponse, 0)
+	for _, category := range categories {
+		parent, ok := byID[parentID(category)]
+		if !ok {
+			roots = append(roots, category)
+			continue
+		}
+		parent.Children = append(parent.Children, category)
+	}
+
+	return roots
+}
+
+func parentID(category *models.CategoryResponse) int64 {
+	if category.ParentID == nil {
+		return 0
+	}
+	return *category.ParentID
+}
@@ -0,0 +1,21 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/Thedrogon/blogbish/post-service/internal/models"
+)
+
+// newDocument wraps an encoded feed or sitemap with a strong ETag derived
+// from its contents
+func newDocument(body []byte, contentType string, lastModified time.Time) *models.Document {
+	sum := sha256.Sum256(body)
+	return &models.Document{
+		Body:         body,
+		ContentType:  contentType,
+		ETag:         `"` + hex.EncodeToString(sum[:16]) + `"`,
+		LastModified: lastModified,
+	}
+}
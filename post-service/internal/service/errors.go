@@ -9,6 +9,8 @@ var (
 	ErrForbidden        = errors.New("forbidden")
 	ErrSlugExists       = errors.New("slug already exists")
 	ErrCategoryNotFound = errors.New("category not found")
+	ErrPostNotFound     = errors.New("post not found")
 	ErrInvalidStatus    = errors.New("invalid status")
 	ErrInvalidOperation = errors.New("invalid operation")
+	ErrPublishAtInPast  = errors.New("publish_at must be in the future")
 )
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/Thedrogon/blogbish/post-service/internal/feed"
+	"github.com/Thedrogon/blogbish/post-service/internal/models"
+	"github.com/Thedrogon/blogbish/post-service/internal/utils"
+)
+
+const (
+	// siteTitle names the site in feed titles
+	siteTitle = "BlogBish"
+
+	// feedSize is the number of posts in a feed, newest first
+	feedSize = 20
+)
+
+// FeedScope selects the posts in a feed. At most one field is set; the zero
+// scope is the whole site.
+type FeedScope struct {
+	Category string // slug
+	Tag      string
+	AuthorID int64
+}
+
+// key identifies the scope in cache keys
+func (sc FeedScope) key() string {
+	switch {
+	case sc.Category != "":
+		return "category:" + sc.Category
+	case sc.Tag != "":
+		return "tag:" + sc.Tag
+	case sc.AuthorID != 0:
+		return "author:" + strconv.FormatInt(sc.AuthorID, 10)
+	default:
+		return "site"
+	}
+}
+
+// Feed returns the latest published posts of scope encoded in format, one of
+// rss, atom or json
+func (s *PostService) Feed(ctx context.Context, scope FeedScope, format string) (*models.Document, error) {
+	contentType, ok := feed.ContentTypes[format]
+	if !ok {
+		return nil, ErrInvalidInput
+	}
+
+	// Every spelling of a tag shares one feed
+	if scope.Tag != "" {
+		scope.Tag = utils.GenerateTagSlug(scope.Tag)
+	}
+
+	key := format + ":" + scope.key()
+	if doc, err := s.cache.GetFeed(ctx, key); err == nil && doc != nil {
+		return doc, nil
+	}
+
+	f, err := s.buildFeed(ctx, scope, format)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := feed.Encode(f, format)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := newDocument(body, contentType, f.Updated)
+
+	s.background.Go(func(ctx context.Context) {
+		_ = s.cache.SetFeed(ctx, key, doc)
+	})
+
+	return doc, nil
+}
+
+func (s *PostService) buildFeed(ctx context.Context, scope FeedScope, format string) (*feed.Feed, error) {
+	filter := &models.PostFilter{
+		Status:   "published",
+		Page:     1,
+		PageSize: feedSize,
+	}
+
+	f := &feed.Feed{
+		Title:       siteTitle,
+		Description: "Latest posts on " + siteTitle,
+		Link:        s.links.API("/posts"),
+		Author:      siteTitle,
+	}
+
+	var path string
+	switch {
+	case scope.Category != "":
+		category, err := s.categoryRepo.GetBySlug(ctx, scope.Category)
+		if err != nil {
+			return nil, ErrCategoryNotFound
+		}
+		subtree, err := s.categoryRepo.Descendants(ctx, category.ID)
+		if err != nil {
+			return nil, err
+		}
+		filter.CategoryIDs = subtree
+		f.Title = fmt.Sprintf("%s: %s", siteTitle, category.Name)
+		f.Description = fmt.Sprintf("Latest posts in %s on %s", category.Name, siteTitle)
+		f.Link = s.links.API("/posts?category=" + url.QueryEscape(category.Slug))
+		path = "/categories/" + url.PathEscape(category.Slug) + "/feed"
+	case scope.Tag != "":
+		tag, err := s.tagRepo.GetBySlug(ctx, scope.Tag)
+		if err != nil {
+			return nil, ErrNotFound
+		}
+		filter.Tags = []string{tag.Slug}
+		f.Title = fmt.Sprintf("%s: #%s", siteTitle, tag.Name)
+		f.Description = fmt.Sprintf("Latest posts tagged %s on %s", tag.Name, siteTitle)
+		f.Link = s.links.API("/posts?tag=" + url.QueryEscape(tag.Slug))
+		path = "/tags/" + url.PathEscape(tag.Slug) + "/feed"
+	case scope.AuthorID != 0:
+		filter.AuthorIDs = []int64{scope.AuthorID}
+		f.Title = fmt.Sprintf("%s: author %d", siteTitle, scope.AuthorID)
+		f.Description = fmt.Sprintf("Latest posts by author %d on %s", scope.AuthorID, siteTitle)
+		path = "/authors/" + strconv.FormatInt(scope.AuthorID, 10) + "/feed"
+	default:
+		path = "/posts/feed"
+	}
+	f.FeedURL = s.links.API(path + "?format=" + format)
+
+	posts, err := s.postRepo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, post := range posts {
+		rendered(post)
+		item := feed.Item{
+			ID:          s.links.EntryID(post.ID, post.CreatedAt),
+			Title:       post.Title,
+			URL:         s.links.Post(post.Slug),
+			Summary:     post.Excerpt,
+			ContentHTML: post.ContentHTML,
+			Categories:  post.Tags,
+			Published:   post.PublishedAt,
+			Updated:     post.UpdatedAt,
+		}
+		if post.Meta.OGImageID != "" {
+			item.Image = s.links.Media(post.Meta.OGImageID)
+		}
+		f.Items = append(f.Items, item)
+
+		if post.UpdatedAt.After(f.Updated) {
+			f.Updated = post.UpdatedAt
+		}
+	}
+
+	return f, nil
+}
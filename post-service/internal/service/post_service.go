@@ -2,10 +2,13 @@ package service
 
 import (
 	"context"
+	"slices"
 	"time"
 
 	"github.com/Thedrogon/blogbish/post-service/internal/cache"
+	"github.com/Thedrogon/blogbish/post-service/internal/links"
 	"github.com/Thedrogon/blogbish/post-service/internal/models"
+	"github.com/Thedrogon/blogbish/post-service/internal/render"
 	"github.com/Thedrogon/blogbish/post-service/internal/repository"
 	"github.com/Thedrogon/blogbish/post-service/internal/utils"
 )
@@ -13,17 +16,33 @@ import (
 type PostService struct {
 	postRepo     repository.PostRepository
 	categoryRepo repository.CategoryRepository
+	revisionRepo repository.RevisionRepository
+	tagRepo      repository.TagRepository
+	seriesRepo   repository.SeriesRepository
 	cache        cache.Cache
+	links        *links.Builder
+	background   *background
 }
 
-func NewPostService(postRepo repository.PostRepository, categoryRepo repository.CategoryRepository, cache cache.Cache) *PostService {
+func NewPostService(postRepo repository.PostRepository, categoryRepo repository.CategoryRepository, revisionRepo repository.RevisionRepository, tagRepo repository.TagRepository, seriesRepo repository.SeriesRepository, cache cache.Cache, links *links.Builder) *PostService {
 	return &PostService{
 		postRepo:     postRepo,
 		categoryRepo: categoryRepo,
+		revisionRepo: revisionRepo,
+		tagRepo:      tagRepo,
+		seriesRepo:   seriesRepo,
 		cache:        cache,
+		links:        links,
+		background:   &background{},
 	}
 }
 
+// Flush waits for background cache and view count writes to complete or for
+// ctx to expire
+func (s *PostService) Flush(ctx context.Context) error {
+	return s.background.Flush(ctx)
+}
+
 func (s *PostService) CreatePost(ctx context.Context, input *models.PostCreate, authorID int64) (*models.PostResponse, error) {
 	// Validate category exists
 	if _, err := s.categoryRepo.GetByID(ctx, input.CategoryID); err != nil {
@@ -36,14 +55,25 @@ func (s *PostService) CreatePost(ctx context.Context, input *models.PostCreate,
 		return err == nil
 	})
 
+	tags, err := s.resolveTags(ctx, input.Tags)
+	if err != nil {
+		return nil, err
+	}
+
 	post := &models.Post{
-		Title:      input.Title,
-		Content:    input.Content,
-		Slug:       slug,
-		AuthorID:   authorID,
-		CategoryID: input.CategoryID,
-		Status:     input.Status,
-		Tags:       input.Tags,
+		Title:         input.Title,
+		Content:       input.Content,
+		ContentFormat: input.ContentFormat,
+		Slug:          slug,
+		AuthorID:      authorID,
+		CategoryID:    input.CategoryID,
+		Status:        input.Status,
+		Tags:          tags,
+		Meta:          input.PostMeta,
+	}
+
+	if err := renderPost(post); err != nil {
+		return nil, err
 	}
 
 	// Set PublishedAt if status is published
@@ -52,10 +82,25 @@ func (s *PostService) CreatePost(ctx context.Context, input *models.PostCreate,
 		post.PublishedAt = now
 	}
 
+	// Scheduled posts are published by the scheduler once publish_at passes
+	if input.Status == "scheduled" {
+		if input.PublishAt == nil || !input.PublishAt.After(time.Now()) {
+			return nil, ErrPublishAtInPast
+		}
+		post.PublishAt = input.PublishAt
+	}
+
 	if err := s.postRepo.Create(ctx, post); err != nil {
 		return nil, err
 	}
 
+	if post.Status == "published" {
+		s.background.Go(func(ctx context.Context) {
+			_ = s.cache.DeleteFeeds(ctx)
+			s.invalidateSitemaps(ctx, post.ID)
+		})
+	}
+
 	return post.ToResponse(), nil
 }
 
@@ -63,11 +108,10 @@ func (s *PostService) GetPost(ctx context.Context, slug string) (*models.PostRes
 	// Try to get from cache first
 	if post, err := s.cache.GetPost(ctx, slug); err == nil && post != nil {
 		// Increment view count asynchronously
-		go func() {
-			ctx := context.Background()
+		s.background.Go(func(ctx context.Context) {
 			_ = s.postRepo.IncrementViewCount(ctx, post.ID)
-		}()
-		return post.ToResponse(), nil
+		})
+		return s.withSeries(ctx, s.withSEO(rendered(post)))
 	}
 
 	// If not in cache, get from database
@@ -76,19 +120,82 @@ func (s *PostService) GetPost(ctx context.Context, slug string) (*models.PostRes
 		return nil, ErrNotFound
 	}
 
+	// Found by a former slug; the caller redirects to the current one,
+	// where the view is counted
+	if post.Slug != slug {
+		return rendered(post).ToResponse(), nil
+	}
+
+	rendered(post)
+
 	// Cache the post
-	go func() {
-		ctx := context.Background()
+	s.background.Go(func(ctx context.Context) {
 		_ = s.cache.SetPost(ctx, post)
-	}()
+	})
 
 	// Increment view count asynchronously
-	go func() {
-		ctx := context.Background()
+	s.background.Go(func(ctx context.Context) {
 		_ = s.postRepo.IncrementViewCount(ctx, post.ID)
-	}()
+	})
 
-	return post.ToResponse(), nil
+	return s.withSeries(ctx, s.withSEO(post))
+}
+
+// withSeries adds the navigation of the series a post is part of, if any.
+// It is looked up on every request, since any part of the series may change.
+func (s *PostService) withSeries(ctx context.Context, response *models.PostResponse) (*models.PostResponse, error) {
+	nav, err := s.seriesRepo.Navigation(ctx, response.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	response.Series = nav
+	return response, nil
+}
+
+// maxMetaDescription is the length search engines show of descriptions
+const maxMetaDescription = 160
+
+// withSEO returns the response for post with its SEO metadata, using the
+// title, excerpt and public URL of the post where the author set none
+func (s *PostService) withSEO(post *models.Post) *models.PostResponse {
+	seo := &models.SEO{
+		Title:        post.Meta.MetaTitle,
+		Description:  post.Meta.MetaDescription,
+		CanonicalURL: post.Meta.CanonicalURL,
+	}
+	if seo.Title == "" {
+		seo.Title = post.Title
+	}
+	if seo.Description == "" {
+		seo.Description = render.Truncate(post.Excerpt, maxMetaDescription)
+	}
+	if seo.CanonicalURL == "" {
+		seo.CanonicalURL = s.links.Post(post.Slug)
+	}
+	if post.Meta.OGImageID != "" {
+		seo.Image = s.links.Media(post.Meta.OGImageID)
+	}
+
+	response := post.ToResponse()
+	response.SEO = seo
+	return response
+}
+
+// mergeMeta copies the fields set in update onto meta
+func mergeMeta(meta *models.PostMeta, update models.PostMeta) {
+	if update.MetaTitle != "" {
+		meta.MetaTitle = update.MetaTitle
+	}
+	if update.MetaDescription != "" {
+		meta.MetaDescription = update.MetaDescription
+	}
+	if update.CanonicalURL != "" {
+		meta.CanonicalURL = update.CanonicalURL
+	}
+	if update.OGImageID != "" {
+		meta.OGImageID = update.OGImageID
+	}
 }
 
 func (s *PostService) UpdatePost(ctx context.Context, slug string, input *models.PostUpdate, authorID int64) (*models.PostResponse, error) {
@@ -106,22 +213,17 @@ func (s *PostService) UpdatePost(ctx context.Context, slug string, input *models
 
 	// Update fields if provided
 	if input.Title != "" {
-		post.Title = input.Title
-		// Generate new slug only if title changes
-		newSlug := utils.GenerateUniqueSlug(input.Title, func(slug string) bool {
-			if slug == post.Slug {
-				return false
-			}
-			_, err := s.postRepo.GetBySlug(ctx, slug)
-			return err == nil
-		})
-		post.Slug = newSlug
+		s.retitle(ctx, post, input.Title)
 	}
 
 	if input.Content != "" {
 		post.Content = input.Content
 	}
 
+	if input.ContentFormat != "" {
+		post.ContentFormat = input.ContentFormat
+	}
+
 	if input.CategoryID != 0 {
 		if _, err := s.categoryRepo.GetByID(ctx, input.CategoryID); err != nil {
 			return nil, ErrCategoryNotFound
@@ -130,10 +232,20 @@ func (s *PostService) UpdatePost(ctx context.Context, slug string, input *models
 	}
 
 	if len(input.Tags) > 0 {
-		post.Tags = input.Tags
+		tags, err := s.resolveTags(ctx, input.Tags)
+		if err != nil {
+			return nil, err
+		}
+		post.Tags = tags
 	}
 
+	mergeMeta(&post.Meta, input.PostMeta)
+
 	if input.Status != "" {
+		// A post that is already public can't be scheduled again
+		if input.Status == "scheduled" && post.Status == "published" {
+			return nil, ErrInvalidOperation
+		}
 		post.Status = input.Status
 		// Set PublishedAt if status changes to published
 		if input.Status == "published" && post.PublishedAt.IsZero() {
@@ -141,23 +253,87 @@ func (s *PostService) UpdatePost(ctx context.Context, slug string, input *models
 		}
 	}
 
-	if err := s.postRepo.Update(ctx, post); err != nil {
+	// publish_at only applies while scheduled; it can be moved without
+	// resending the status
+	if post.Status == "scheduled" {
+		if input.PublishAt != nil {
+			if !input.PublishAt.After(time.Now()) {
+				return nil, ErrPublishAtInPast
+			}
+			post.PublishAt = input.PublishAt
+		}
+		if post.PublishAt == nil {
+			return nil, ErrPublishAtInPast
+		}
+	} else {
+		post.PublishAt = nil
+	}
+
+	return s.save(ctx, post, oldSlug, authorID)
+}
+
+// retitle sets the title of post and generates a new slug from it
+func (s *PostService) retitle(ctx context.Context, post *models.Post, title string) {
+	post.Title = title
+	post.Slug = utils.GenerateUniqueSlug(title, func(slug string) bool {
+		// A post may take back one of its own former slugs
+		other, err := s.postRepo.GetBySlug(ctx, slug)
+		return err == nil && other.ID != post.ID
+	})
+}
+
+// save stores post as a new revision by editorID and refreshes the cache
+func (s *PostService) save(ctx context.Context, post *models.Post, oldSlug string, editorID int64) (*models.PostResponse, error) {
+	if err := renderPost(post); err != nil {
+		return nil, err
+	}
+
+	if err := s.postRepo.Update(ctx, post, editorID); err != nil {
 		return nil, err
 	}
 
 	// Update cache
-	go func() {
-		ctx := context.Background()
+	s.background.Go(func(ctx context.Context) {
 		// Delete old cache entry if slug changed
 		if oldSlug != post.Slug {
 			_ = s.cache.DeletePost(ctx, oldSlug)
 		}
 		_ = s.cache.SetPost(ctx, post)
-	}()
+		_ = s.cache.DeleteFeeds(ctx)
+		s.invalidateSitemaps(ctx, post.ID)
+	})
 
 	return post.ToResponse(), nil
 }
 
+// renderPost stores the HTML, table of contents and reading time of the
+// post's content alongside it, so reads don't pay for rendering
+func renderPost(post *models.Post) error {
+	if post.ContentFormat == "" {
+		post.ContentFormat = models.FormatMarkdown
+	}
+
+	result, err := render.Render(post.ContentFormat, post.Content)
+	if err != nil {
+		return err
+	}
+
+	post.ContentHTML = result.HTML
+	post.TOC = result.TOC
+	post.Excerpt = result.Excerpt
+	post.ReadingTime = result.ReadingTime
+	return nil
+}
+
+// rendered fills in the HTML and excerpt of posts written before they were
+// generated on save. The next update stores them.
+func rendered(post *models.Post) *models.Post {
+	if post.Content != "" && (post.ContentHTML == "" || post.Excerpt == "") {
+		_ = renderPost(post)
+	}
+	return post
+}
+
 func (s *PostService) DeletePost(ctx context.Context, slug string, authorID int64) error {
 	post, err := s.postRepo.GetBySlug(ctx, slug)
 	if err != nil {
@@ -174,24 +350,84 @@ func (s *PostService) DeletePost(ctx context.Context, slug string, authorID int6
 	}
 
 	// Delete from cache
-	go func() {
-		ctx := context.Background()
-		_ = s.cache.DeletePost(ctx, slug)
-	}()
+	s.background.Go(func(ctx context.Context) {
+		_ = s.cache.DeletePost(ctx, post.Slug)
+		_ = s.cache.DeleteFeeds(ctx)
+		s.invalidateSitemaps(ctx, post.ID)
+	})
 
 	return nil
 }
 
-func (s *PostService) ListPosts(ctx context.Context, filter *models.PostFilter) ([]*models.PostResponse, error) {
-	posts, err := s.postRepo.List(ctx, filter)
+// ListPosts returns a page of posts matching filter, newest first. The page
+// carries the total number of matches and, unless it is the last one, the
+// cursor of the next page.
+func (s *PostService) ListPosts(ctx context.Context, filter *models.PostFilter) (*models.PostPage, error) {
+	// Tags and categories are resolved on a copy: cursors are bound to the
+	// filter as the client sent it
+	resolved := *filter
+	resolved.Tags = s.lookupTags(ctx, filter.Tags)
+	resolved.CategoryIDs = slices.Clone(filter.CategoryIDs)
+
+	// A category lists the posts of its subcategories too
+	for _, slug := range filter.CategorySlugs {
+		category, err := s.categoryRepo.GetBySlug(ctx, slug)
+		if err != nil {
+			return nil, ErrCategoryNotFound
+		}
+		subtree, err := s.categoryRepo.Descendants(ctx, category.ID)
+		if err != nil {
+			return nil, err
+		}
+		resolved.CategoryIDs = append(resolved.CategoryIDs, subtree...)
+	}
+
+	// Fetch one post more than asked for to learn whether another page follows
+	query := resolved
+	query.Lookahead = true
+
+	posts, err := s.postRepo.List(ctx, &query)
 	if err != nil {
 		return nil, err
 	}
 
+	total, err := s.postRepo.Count(ctx, &resolved)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &models.PostPage{Total: total}
+	if filter.PageSize > 0 && len(posts) > filter.PageSize {
+		posts = posts[:filter.PageSize]
+		page.Next = models.CursorAfter(filter, posts[len(posts)-1])
+	}
+
 	// Convert to response objects
+	page.Posts = make([]*models.PostResponse, len(posts))
+	for i, post := range posts {
+		page.Posts[i] = rendered(post).ToResponse()
+	}
+
+	return page, nil
+}
+
+// maxScheduledPosts caps the number of upcoming posts listed at once
+const maxScheduledPosts = 100
+
+// ListScheduled returns up to limit scheduled posts, soonest first
+func (s *PostService) ListScheduled(ctx context.Context, limit int) ([]*models.PostResponse, error) {
+	if limit < 1 || limit > maxScheduledPosts {
+		limit = maxScheduledPosts
+	}
+
+	posts, err := s.postRepo.ListScheduled(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+
 	responses := make([]*models.PostResponse, len(posts))
 	for i, post := range posts {
-		responses[i] = post.ToResponse()
+		responses[i] = rendered(post).ToResponse()
 	}
 
 	return responses, nil
@@ -199,7 +435,11 @@ func (s *PostService) ListPosts(ctx context.Context, filter *models.PostFilter)
 
 func (s *PostService) GetUserPosts(ctx context.Context, authorID int64) ([]*models.PostResponse, error) {
 	filter := &models.PostFilter{
-		AuthorID: authorID,
+		AuthorIDs: []int64{authorID},
+	}
+	page, err := s.ListPosts(ctx, filter)
+	if err != nil {
+		return nil, err
 	}
-	return s.ListPosts(ctx, filter)
+	return page.Posts, nil
 }
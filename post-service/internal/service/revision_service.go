@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Thedrogon/blogbish/post-service/internal/diff"
+	"github.com/Thedrogon/blogbish/post-service/internal/models"
+)
+
+// ListRevisions returns the history of a post, newest first
+func (s *PostService) ListRevisions(ctx context.Context, slug string) ([]*models.PostRevisionResponse, error) {
+	post, err := s.postRepo.GetBySlug(ctx, slug)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	revisions, err := s.revisionRepo.List(ctx, post.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*models.PostRevisionResponse, len(revisions))
+	for i, revision := range revisions {
+		responses[i] = revision.ToResponse()
+	}
+
+	return responses, nil
+}
+
+func (s *PostService) GetRevision(ctx context.Context, slug string, revision int) (*models.PostRevisionResponse, error) {
+	post, err := s.postRepo.GetBySlug(ctx, slug)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	rev, err := s.revisionRepo.Get(ctx, post.ID, revision)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	return rev.ToResponse(), nil
+}
+
+// DiffRevisions returns a unified diff from one revision to another. A zero
+// to compares against the latest revision.
+func (s *PostService) DiffRevisions(ctx context.Context, slug string, from, to int) (*models.RevisionDiff, error) {
+	post, err := s.postRepo.GetBySlug(ctx, slug)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	if to == 0 {
+		revisions, err := s.revisionRepo.List(ctx, post.ID)
+		if err != nil {
+			return nil, err
+		}
+		if len(revisions) == 0 {
+			return nil, ErrNotFound
+		}
+		to = revisions[0].Revision
+	}
+
+	older, err := s.revisionRepo.Get(ctx, post.ID, from)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+	newer, err := s.revisionRepo.Get(ctx, post.ID, to)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	return &models.RevisionDiff{
+		From: from,
+		To:   to,
+		Diff: diff.Unified(revisionLabel(older), revisionLabel(newer), revisionText(older), revisionText(newer)),
+	}, nil
+}
+
+// RestoreRevision copies an older revision back onto the post. The restore
+// is recorded as a new revision, so history is never rewritten.
+func (s *PostService) RestoreRevision(ctx context.Context, slug string, revision int, authorID int64) (*models.PostResponse, error) {
+	post, err := s.postRepo.GetBySlug(ctx, slug)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	// Check if user is the author
+	if post.AuthorID != authorID {
+		return nil, ErrForbidden
+	}
+
+	rev, err := s.revisionRepo.Get(ctx, post.ID, revision)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	// The category may have been deleted since the revision was made
+	if rev.CategoryID != post.CategoryID {
+		if _, err := s.categoryRepo.GetByID(ctx, rev.CategoryID); err != nil {
+			return nil, ErrCategoryNotFound
+		}
+	}
+
+	oldSlug := post.Slug
+	if rev.Title != post.Title {
+		s.retitle(ctx, post, rev.Title)
+	}
+	post.Content = rev.Content
+	post.ContentFormat = rev.ContentFormat
+	post.CategoryID = rev.CategoryID
+
+	// Tags may have been renamed or merged since
+	tags, err := s.resolveTags(ctx, rev.Tags)
+	if err != nil {
+		return nil, err
+	}
+	post.Tags = tags
+
+	return s.save(ctx, post, oldSlug, authorID)
+}
+
+func revisionLabel(rev *models.PostRevision) string {
+	return fmt.Sprintf("revision %d\t%s", rev.Revision, rev.CreatedAt.UTC().Format("2006-01-02 15:04:05 MST"))
+}
+
+// revisionText renders the fields of a revision as one document so a single
+// diff covers metadata and content
+func revisionText(rev *models.PostRevision) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Title: %s\n", rev.Title)
+	fmt.Fprintf(&b, "Category: %d\n", rev.CategoryID)
+	fmt.Fprintf(&b, "Format: %s\n", rev.ContentFormat)
+	fmt.Fprintf(&b, "Tags: %s\n", strings.Join(rev.Tags, ", "))
+	b.WriteString("\n")
+	b.WriteString(rev.Content)
+	return b.String()
+}
@@ -0,0 +1,244 @@
+package service
+
+import (
+	"context"
+
+	"github.com/Thedrogon/blogbish/post-service/internal/models"
+	"github.com/Thedrogon/blogbish/post-service/internal/repository"
+	"github.com/Thedrogon/blogbish/post-service/internal/utils"
+)
+
+type SeriesService struct {
+	seriesRepo repository.SeriesRepository
+	postRepo   repository.PostRepository
+}
+
+func NewSeriesService(seriesRepo repository.SeriesRepository, postRepo repository.PostRepository) *SeriesService {
+	return &SeriesService{
+		seriesRepo: seriesRepo,
+		postRepo:   postRepo,
+	}
+}
+
+// CreateSeries adds a series, with the posts of input as its first parts
+func (s *SeriesService) CreateSeries(ctx context.Context, input *models.SeriesCreate, authorID int64) (*models.SeriesResponse, error) {
+	seen := make(map[int64]bool, len(input.PostIDs))
+	for _, postID := range input.PostIDs {
+		if seen[postID] {
+			return nil, ErrInvalidInput
+		}
+		seen[postID] = true
+
+		if err := s.checkPost(ctx, postID, authorID); err != nil {
+			return nil, err
+		}
+	}
+
+	slug := utils.GenerateUniqueSlug(input.Title, func(slug string) bool {
+		_, err := s.seriesRepo.GetBySlug(ctx, slug)
+		return err == nil
+	})
+
+	series := &models.Series{
+		Title:       input.Title,
+		Slug:        slug,
+		Description: input.Description,
+		AuthorID:    authorID,
+	}
+
+	if err := s.seriesRepo.Create(ctx, series, input.PostIDs); err != nil {
+		return nil, err
+	}
+
+	return s.withParts(ctx, series)
+}
+
+// GetSeries returns the landing page of a series: the series with its
+// published parts in order
+func (s *SeriesService) GetSeries(ctx context.Context, slug string) (*models.SeriesResponse, error) {
+	series, err := s.seriesRepo.GetBySlug(ctx, slug)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	// Found by a former slug; the caller redirects to the current one
+	if series.Slug != slug {
+		return series.ToResponse(), nil
+	}
+
+	return s.withParts(ctx, series)
+}
+
+func (s *SeriesService) ListSeries(ctx context.Context) ([]*models.SeriesResponse, error) {
+	list, err := s.seriesRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*models.SeriesResponse, len(list))
+	for i, series := range list {
+		responses[i] = series.ToResponse()
+	}
+
+	return responses, nil
+}
+
+func (s *SeriesService) UpdateSeries(ctx context.Context, slug string, input *models.SeriesUpdate, authorID int64) (*models.SeriesResponse, error) {
+	series, err := s.owned(ctx, slug, authorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Title != "" {
+		series.Title = input.Title
+		series.Slug = utils.GenerateUniqueSlug(input.Title, func(slug string) bool {
+			// A series may take back one of its own former slugs
+			other, err := s.seriesRepo.GetBySlug(ctx, slug)
+			return err == nil && other.ID != series.ID
+		})
+	}
+
+	if input.Description != "" {
+		series.Description = input.Description
+	}
+
+	if err := s.seriesRepo.Update(ctx, series); err != nil {
+		return nil, err
+	}
+
+	return s.withParts(ctx, series)
+}
+
+// DeleteSeries removes a series; its posts are kept
+func (s *SeriesService) DeleteSeries(ctx context.Context, slug string, authorID int64) error {
+	series, err := s.owned(ctx, slug, authorID)
+	if err != nil {
+		return err
+	}
+
+	return s.seriesRepo.Delete(ctx, series.ID)
+}
+
+// AddPost adds a post to a series. A post belongs to at most one series.
+func (s *SeriesService) AddPost(ctx context.Context, slug string, input *models.SeriesPostAdd, authorID int64) (*models.SeriesResponse, error) {
+	series, err := s.owned(ctx, slug, authorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkPost(ctx, input.PostID, authorID); err != nil {
+		return nil, err
+	}
+
+	if err := s.seriesRepo.AddPost(ctx, series.ID, input.PostID, input.Part); err != nil {
+		return nil, err
+	}
+
+	return s.GetSeries(ctx, series.Slug)
+}
+
+// RemovePost takes a post out of a series; later parts move up
+func (s *SeriesService) RemovePost(ctx context.Context, slug string, postID int64, authorID int64) error {
+	series, err := s.owned(ctx, slug, authorID)
+	if err != nil {
+		return err
+	}
+
+	nav, err := s.seriesRepo.Navigation(ctx, postID)
+	if err != nil {
+		return err
+	}
+	if nav == nil || nav.ID != series.ID {
+		return ErrNotFound
+	}
+
+	return s.seriesRepo.RemovePost(ctx, series.ID, postID)
+}
+
+// ReorderPosts puts the posts of a series in the order given, which must list
+// every one of them once
+func (s *SeriesService) ReorderPosts(ctx context.Context, slug string, input *models.SeriesReorder, authorID int64) (*models.SeriesResponse, error) {
+	series, err := s.owned(ctx, slug, authorID)
+	if err != nil {
+		return nil, err
+	}
+
+	parts, err := s.seriesRepo.Parts(ctx, series.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(parts) != len(input.PostIDs) {
+		return nil, ErrInvalidInput
+	}
+	members := make(map[int64]bool, len(parts))
+	for _, part := range parts {
+		members[part.ID] = true
+	}
+	for _, postID := range input.PostIDs {
+		if !members[postID] {
+			return nil, ErrInvalidInput
+		}
+		delete(members, postID) // listed twice otherwise
+	}
+
+	if err := s.seriesRepo.Reorder(ctx, series.ID, input.PostIDs); err != nil {
+		return nil, err
+	}
+
+	return s.GetSeries(ctx, series.Slug)
+}
+
+// owned returns the series with slug if authorID may change it
+func (s *SeriesService) owned(ctx context.Context, slug string, authorID int64) (*models.Series, error) {
+	series, err := s.seriesRepo.GetBySlug(ctx, slug)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	if series.AuthorID != authorID {
+		return nil, ErrForbidden
+	}
+
+	return series, nil
+}
+
+// checkPost ensures authorID may add post postID to a series, which it must
+// not already be part of
+func (s *SeriesService) checkPost(ctx context.Context, postID int64, authorID int64) error {
+	post, err := s.postRepo.GetByID(ctx, postID)
+	if err != nil {
+		return ErrPostNotFound
+	}
+
+	if post.AuthorID != authorID {
+		return ErrForbidden
+	}
+
+	nav, err := s.seriesRepo.Navigation(ctx, postID)
+	if err != nil {
+		return err
+	}
+	if nav != nil {
+		return ErrInvalidOperation
+	}
+
+	return nil
+}
+
+// withParts returns the response for series listing its published parts
+func (s *SeriesService) withParts(ctx context.Context, series *models.Series) (*models.SeriesResponse, error) {
+	parts, err := s.seriesRepo.Parts(ctx, series.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := series.ToResponse()
+	response.Parts = len(parts)
+	for _, part := range parts {
+		if part.Status == "published" {
+			response.Posts = append(response.Posts, part)
+		}
+	}
+
+	return response, nil
+}
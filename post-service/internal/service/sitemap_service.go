@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/Thedrogon/blogbish/post-service/internal/models"
+	"github.com/Thedrogon/blogbish/post-service/internal/sitemap"
+)
+
+const sitemapContentType = "application/xml; charset=utf-8"
+
+// Sitemap returns the sitemap index, or the sitemap with the given name as
+// listed in it. Sitemaps are cached until a post or category they list
+// changes.
+func (s *PostService) Sitemap(ctx context.Context, name string) (*models.Document, error) {
+	// Only names the index can list are looked up, so arbitrary names never
+	// reach the cache
+	if _, _, ok := sitemap.Parse(name); !ok && name != sitemap.Index {
+		return nil, ErrNotFound
+	}
+
+	if doc, err := s.cache.GetSitemap(ctx, name); err == nil && doc != nil {
+		return doc, nil
+	}
+
+	var (
+		doc *models.Document
+		err error
+	)
+	if name == sitemap.Index {
+		doc, err = s.sitemapIndex(ctx)
+	} else {
+		doc, err = s.sitemapPage(ctx, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.background.Go(func(ctx context.Context) {
+		_ = s.cache.SetSitemap(ctx, name, doc)
+	})
+
+	return doc, nil
+}
+
+func (s *PostService) sitemapIndex(ctx context.Context) (*models.Document, error) {
+	postPages, err := s.postRepo.SitemapPages(ctx, sitemap.MaxURLs)
+	if err != nil {
+		return nil, err
+	}
+	categoryPages, err := s.categoryRepo.SitemapPages(ctx, sitemap.MaxURLs)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		sitemaps     []sitemap.URL
+		lastModified = latest(postPages)
+	)
+	for _, page := range postPages {
+		sitemaps = append(sitemaps, sitemap.URL{
+			Loc:          s.sitemapURL(sitemap.Name(sitemap.Posts, page.Page)),
+			LastModified: page.LastModified,
+		})
+	}
+	for _, page := range categoryPages {
+		sitemaps = append(sitemaps, sitemap.URL{
+			Loc:          s.sitemapURL(sitemap.Name(sitemap.Categories, page.Page)),
+			LastModified: page.LastModified,
+		})
+	}
+	if t := latest(categoryPages); t.After(lastModified) {
+		lastModified = t
+	}
+
+	body, err := sitemap.EncodeIndex(sitemaps)
+	if err != nil {
+		return nil, err
+	}
+	return newDocument(body, sitemapContentType, lastModified), nil
+}
+
+func (s *PostService) sitemapPage(ctx context.Context, name string) (*models.Document, error) {
+	kind, page, ok := sitemap.Parse(name)
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	var (
+		entries []models.SitemapEntry
+		locate  func(slug string) string
+		err     error
+	)
+	switch kind {
+	case sitemap.Posts:
+		entries, err = s.postRepo.SitemapEntries(ctx, page, sitemap.MaxURLs)
+		locate = s.links.Post
+	case sitemap.Categories:
+		entries, err = s.categoryRepo.SitemapEntries(ctx, page, sitemap.MaxURLs)
+		locate = s.links.Category
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, ErrNotFound
+	}
+
+	urls := make([]sitemap.URL, len(entries))
+	lastModified := entries[0].LastModified
+	for i, entry := range entries {
+		urls[i] = sitemap.URL{Loc: locate(entry.Slug), LastModified: entry.LastModified}
+		if entry.LastModified.After(lastModified) {
+			lastModified = entry.LastModified
+		}
+	}
+
+	body, err := sitemap.EncodeURLSet(urls)
+	if err != nil {
+		return nil, err
+	}
+	return newDocument(body, sitemapContentType, lastModified), nil
+}
+
+func (s *PostService) sitemapURL(name string) string {
+	return s.links.API("/sitemap-" + name + ".xml")
+}
+
+// invalidateSitemaps drops the cached sitemap listing the post with id and
+// the index that dates it
+func (s *PostService) invalidateSitemaps(ctx context.Context, id int64) {
+	_ = s.cache.DeleteSitemaps(ctx, sitemap.Index, sitemap.Name(sitemap.Posts, sitemap.Page(id)))
+}
+
+func latest(pages []models.SitemapPage) time.Time {
+	var t time.Time
+	for _, page := range pages {
+		if page.LastModified.After(t) {
+			t = page.LastModified
+		}
+	}
+	return t
+}
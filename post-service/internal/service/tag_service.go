@@ -0,0 +1,268 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/Thedrogon/blogbish/post-service/internal/cache"
+	"github.com/Thedrogon/blogbish/post-service/internal/models"
+	"github.com/Thedrogon/blogbish/post-service/internal/repository"
+	"github.com/Thedrogon/blogbish/post-service/internal/utils"
+)
+
+// maxCloudTags caps the number of tags in a tag cloud
+const maxCloudTags = 100
+
+type TagService struct {
+	tagRepo    repository.TagRepository
+	cache      cache.Cache
+	background *background
+}
+
+func NewTagService(tagRepo repository.TagRepository, cache cache.Cache) *TagService {
+	return &TagService{
+		tagRepo:    tagRepo,
+		cache:      cache,
+		background: &background{},
+	}
+}
+
+// Flush waits for background cache writes to complete or for ctx to expire
+func (s *TagService) Flush(ctx context.Context) error {
+	return s.background.Flush(ctx)
+}
+
+// GetTag returns a tag by slug or alias, with its aliases
+func (s *TagService) GetTag(ctx context.Context, slug string) (*models.TagResponse, error) {
+	tag, err := s.tagRepo.GetBySlug(ctx, utils.GenerateTagSlug(slug))
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	aliases, err := s.tagRepo.Aliases(ctx, tag.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := tag.ToResponse()
+	response.Aliases = aliases
+	return response, nil
+}
+
+func (s *TagService) ListTags(ctx context.Context) ([]*models.TagResponse, error) {
+	tags, err := s.tagRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*models.TagResponse, len(tags))
+	for i, tag := range tags {
+		responses[i] = tag.ToResponse()
+	}
+
+	return responses, nil
+}
+
+// TagCloud returns up to limit of the most used tags, alphabetically, each
+// weighted by its post count relative to the others
+func (s *TagService) TagCloud(ctx context.Context, limit int) ([]*models.TagCloudEntry, error) {
+	if limit < 1 || limit > maxCloudTags {
+		limit = maxCloudTags
+	}
+
+	tags, err := s.tagRepo.Popular(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*models.TagCloudEntry, 0, len(tags))
+	if len(tags) == 0 {
+		return entries, nil
+	}
+
+	// Popular lists the most used tag first and the least used last
+	most, least := tags[0].PostCount, tags[len(tags)-1].PostCount
+	for _, tag := range tags {
+		weight := 1
+		if most > least {
+			weight += int((tag.PostCount - least) * (models.CloudWeights - 1) / (most - least))
+		}
+		entries = append(entries, &models.TagCloudEntry{
+			Name:      tag.Name,
+			Slug:      tag.Slug,
+			PostCount: tag.PostCount,
+			Weight:    weight,
+		})
+	}
+
+	slices.SortFunc(entries, func(a, b *models.TagCloudEntry) int {
+		return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	})
+
+	return entries, nil
+}
+
+// UpdateTag renames or describes a tag. Renaming changes its slug; the old
+// one is kept as an alias.
+func (s *TagService) UpdateTag(ctx context.Context, slug string, input *models.TagUpdate) (*models.TagResponse, error) {
+	tag, err := s.tagRepo.GetBySlug(ctx, utils.GenerateTagSlug(slug))
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	oldSlug := tag.Slug
+
+	if input.Name != "" {
+		name := utils.NormalizeTagName(input.Name)
+		newSlug := utils.GenerateTagSlug(name)
+		if newSlug == "" {
+			return nil, ErrInvalidInput
+		}
+		// Tag slugs identify the tag, so unlike categories a clash is an
+		// error rather than a reason to pick another slug
+		if other, err := s.tagRepo.GetBySlug(ctx, newSlug); err == nil && other.ID != tag.ID {
+			return nil, ErrSlugExists
+		}
+		tag.Name = name
+		tag.Slug = newSlug
+	}
+
+	if input.Description != "" {
+		tag.Description = input.Description
+	}
+
+	if err := s.tagRepo.Update(ctx, tag); err != nil {
+		return nil, err
+	}
+
+	if oldSlug != tag.Slug {
+		s.invalidatePosts()
+	}
+
+	return tag.ToResponse(), nil
+}
+
+// AddAlias makes another spelling resolve to a tag
+func (s *TagService) AddAlias(ctx context.Context, slug, alias string) (*models.TagResponse, error) {
+	tag, err := s.tagRepo.GetBySlug(ctx, utils.GenerateTagSlug(slug))
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	aliasSlug := utils.GenerateTagSlug(alias)
+	if aliasSlug == "" {
+		return nil, ErrInvalidInput
+	}
+	if other, err := s.tagRepo.GetBySlug(ctx, aliasSlug); err == nil {
+		if other.ID == tag.ID {
+			return s.GetTag(ctx, tag.Slug)
+		}
+		// Tags that are in use are merged instead
+		return nil, ErrSlugExists
+	}
+
+	if err := s.tagRepo.AddAlias(ctx, tag.ID, aliasSlug); err != nil {
+		return nil, err
+	}
+
+	return s.GetTag(ctx, tag.Slug)
+}
+
+func (s *TagService) RemoveAlias(ctx context.Context, slug, alias string) error {
+	tag, err := s.tagRepo.GetBySlug(ctx, utils.GenerateTagSlug(slug))
+	if err != nil {
+		return ErrNotFound
+	}
+
+	if err := s.tagRepo.RemoveAlias(ctx, tag.ID, utils.GenerateTagSlug(alias)); err != nil {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// MergeTag folds the tag slug into another, which takes over its posts and
+// keeps its slug and aliases as aliases
+func (s *TagService) MergeTag(ctx context.Context, slug string, input *models.TagMerge) (*models.TagResponse, error) {
+	from, err := s.tagRepo.GetBySlug(ctx, utils.GenerateTagSlug(slug))
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	into, err := s.tagRepo.GetBySlug(ctx, utils.GenerateTagSlug(input.Into))
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	if from.ID == into.ID {
+		return nil, ErrInvalidOperation
+	}
+
+	if err := s.tagRepo.Merge(ctx, from, into); err != nil {
+		return nil, err
+	}
+
+	s.invalidatePosts()
+
+	return s.GetTag(ctx, into.Slug)
+}
+
+// invalidatePosts drops cached posts and feeds after posts were retagged
+func (s *TagService) invalidatePosts() {
+	s.background.Go(func(ctx context.Context) {
+		_ = s.cache.DeletePosts(ctx)
+		_ = s.cache.DeleteFeeds(ctx)
+	})
+}
+
+// tagNames normalizes tags as written by authors, dropping blanks and
+// duplicates
+func tagNames(names []string) []*models.Tag {
+	seen := make(map[string]bool, len(names))
+	tags := make([]*models.Tag, 0, len(names))
+	for _, name := range names {
+		name = utils.NormalizeTagName(name)
+		slug := utils.GenerateTagSlug(name)
+		if slug == "" || seen[slug] {
+			continue
+		}
+		seen[slug] = true
+		tags = append(tags, &models.Tag{Name: name, Slug: slug})
+	}
+	return tags
+}
+
+// resolveTags turns tags as written by authors into the slugs of canonical
+// tags, creating tags seen for the first time
+func (s *PostService) resolveTags(ctx context.Context, names []string) ([]string, error) {
+	slugs, err := s.tagRepo.Resolve(ctx, tagNames(names))
+	if err != nil {
+		return nil, err
+	}
+
+	// Distinct spellings may be aliases of the same tag
+	seen := make(map[string]bool, len(slugs))
+	unique := slugs[:0]
+	for _, slug := range slugs {
+		if !seen[slug] {
+			seen[slug] = true
+			unique = append(unique, slug)
+		}
+	}
+	return unique, nil
+}
+
+// lookupTags turns tags in a filter into the slugs of canonical tags. Unknown
+// tags are kept normalized; they match no posts.
+func (s *PostService) lookupTags(ctx context.Context, names []string) []string {
+	tags := tagNames(names)
+	slugs := make([]string, len(tags))
+	for i, tag := range tags {
+		slugs[i] = tag.Slug
+		if canonical, err := s.tagRepo.GetBySlug(ctx, tag.Slug); err == nil {
+			slugs[i] = canonical.Slug
+		}
+	}
+	return slugs
+}
@@ -0,0 +1,96 @@
+// Package sitemap encodes sitemaps and sitemap indexes (sitemaps.org 0.9).
+//
+// Posts and categories are split into sitemaps by ID range, MaxURLs IDs
+// each, rather than by position. A sitemap then never exceeds the protocol's
+// limit, and changing one post only invalidates the sitemap holding it.
+package sitemap
+
+import (
+	"encoding/xml"
+	"fmt"
+	"time"
+)
+
+// MaxURLs is the most URLs the protocol allows in one sitemap
+const MaxURLs = 50000
+
+// Index is the name of the sitemap index
+const Index = "index"
+
+// Kinds of sitemap
+const (
+	Posts      = "posts"
+	Categories = "categories"
+)
+
+// Page returns the number of the sitemap holding the resource with id
+func Page(id int64) int {
+	return int((id-1)/MaxURLs) + 1
+}
+
+// Name identifies page n of kind, e.g. posts-2; sitemaps are served as
+// /sitemap-{name}.xml, beside the index, since a sitemap may only list URLs
+// below its own directory
+func Name(kind string, n int) string {
+	return fmt.Sprintf("%s-%d", kind, n)
+}
+
+// Parse splits a sitemap name into its kind and page
+func Parse(name string) (kind string, n int, ok bool) {
+	for _, k := range []string{Posts, Categories} {
+		if _, err := fmt.Sscanf(name, k+"-%d", &n); err == nil && n > 0 && Name(k, n) == name {
+			return k, n, true
+		}
+	}
+	return "", 0, false
+}
+
+// URL is an entry of a sitemap or of a sitemap index
+type URL struct {
+	Loc          string
+	LastModified time.Time
+}
+
+type urlSet struct {
+	XMLName xml.Name `xml:"http://www.sitemaps.org/schemas/sitemap/0.9 urlset"`
+	URLs    []entry  `xml:"url"`
+}
+
+type index struct {
+	XMLName  xml.Name `xml:"http://www.sitemaps.org/schemas/sitemap/0.9 sitemapindex"`
+	Sitemaps []entry  `xml:"sitemap"`
+}
+
+type entry struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// EncodeURLSet renders a sitemap of urls
+func EncodeURLSet(urls []URL) ([]byte, error) {
+	return encode(&urlSet{URLs: entries(urls)})
+}
+
+// EncodeIndex renders a sitemap index pointing at sitemaps
+func EncodeIndex(sitemaps []URL) ([]byte, error) {
+	return encode(&index{Sitemaps: entries(sitemaps)})
+}
+
+func entries(urls []URL) []entry {
+	out := make([]entry, len(urls))
+	for i, u := range urls {
+		out[i] = entry{Loc: u.Loc}
+		if !u.LastModified.IsZero() {
+			out[i].LastMod = u.LastModified.UTC().Format(time.RFC3339)
+		}
+	}
+	return out
+}
+
+func encode(v interface{}) ([]byte, error) {
+	body, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("error encoding sitemap: %w", err)
+	}
+	return append([]byte(xml.Header), body...), nil
+}
@@ -0,0 +1,127 @@
+package sitemap
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPage(t *testing.T) {
+	tests := []struct {
+		id   int64
+		want int
+	}{
+		{1, 1},
+		{MaxURLs, 1},
+		{MaxURLs + 1, 2},
+		{2 * MaxURLs, 2},
+		{2*MaxURLs + 1, 3},
+	}
+
+	for _, tt := range tests {
+		if got := Page(tt.id); got != tt.want {
+			t.Errorf("Page(%d) = %d, want %d", tt.id, got, tt.want)
+		}
+	}
+}
+
+func TestPageNeverExceedsMaxURLs(t *testing.T) {
+	counts := make(map[int]int)
+	for id := int64(1); id <= 3*MaxURLs+7; id++ {
+		counts[Page(id)]++
+	}
+	for page, n := range counts {
+		if n > MaxURLs {
+			t.Errorf("page %d holds %d IDs, want at most %d", page, n, MaxURLs)
+		}
+	}
+	if len(counts) != 4 {
+		t.Errorf("got %d pages, want 4", len(counts))
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		kind string
+		n    int
+		ok   bool
+	}{
+		{"posts-1", Posts, 1, true},
+		{"posts-12", Posts, 12, true},
+		{"categories-3", Categories, 3, true},
+		{Index, "", 0, false},
+		{"posts", "", 0, false},
+		{"posts-", "", 0, false},
+		{"posts-0", "", 0, false},
+		{"posts--1", "", 0, false},
+		{"posts-01", "", 0, false},
+		{"posts-1x", "", 0, false},
+		{"posts-1.xml", "", 0, false},
+		{"tags-1", "", 0, false},
+		{"posts-1 ", "", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kind, n, ok := Parse(tt.name)
+			if kind != tt.kind || n != tt.n || ok != tt.ok {
+				t.Errorf("Parse(%q) = %q, %d, %v; want %q, %d, %v", tt.name, kind, n, ok, tt.kind, tt.n, tt.ok)
+			}
+		})
+	}
+}
+
+func TestNameRoundTrip(t *testing.T) {
+	for _, kind := range []string{Posts, Categories} {
+		for _, n := range []int{1, 2, 99} {
+			gotKind, gotN, ok := Parse(Name(kind, n))
+			if !ok || gotKind != kind || gotN != n {
+				t.Errorf("Parse(Name(%q, %d)) = %q, %d, %v", kind, n, gotKind, gotN, ok)
+			}
+		}
+	}
+}
+
+func TestEncodeURLSet(t *testing.T) {
+	modified := time.Date(2024, 3, 1, 12, 0, 0, 0, time.FixedZone("CET", 3600))
+	data, err := EncodeURLSet([]URL{
+		{Loc: "https://example.com/v2/posts/a&b", LastModified: modified},
+		{Loc: "https://example.com/v2/categories/go"},
+	})
+	if err != nil {
+		t.Fatalf("EncodeURLSet() error = %v", err)
+	}
+
+	want := xml.Header + `<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <url>
+    <loc>https://example.com/v2/posts/a&amp;b</loc>
+    <lastmod>2024-03-01T11:00:00Z</lastmod>
+  </url>
+  <url>
+    <loc>https://example.com/v2/categories/go</loc>
+  </url>
+</urlset>`
+	if string(data) != want {
+		t.Errorf("EncodeURLSet() =\n%s\nwant\n%s", data, want)
+	}
+}
+
+func TestEncodeIndex(t *testing.T) {
+	data, err := EncodeIndex([]URL{
+		{Loc: "https://example.com/sitemap-posts-1.xml", LastModified: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)},
+	})
+	if err != nil {
+		t.Fatalf("EncodeIndex() error = %v", err)
+	}
+
+	for _, want := range []string{
+		`<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`,
+		"<sitemap>\n    <loc>https://example.com/sitemap-posts-1.xml</loc>\n    <lastmod>2024-01-02T03:04:05Z</lastmod>\n  </sitemap>",
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("EncodeIndex() =\n%s\nwant it to contain\n%s", data, want)
+		}
+	}
+}
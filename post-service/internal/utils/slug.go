@@ -11,6 +11,12 @@ var (
 	nonAlphanumericRegex = regexp.MustCompile(`[^a-zA-Z0-9-]`)
 	// Match multiple hyphens
 	multipleHyphenRegex = regexp.MustCompile(`-+`)
+
+	// Slugs that would shadow fixed routes under /posts
+	reservedSlugs = map[string]bool{
+		"scheduled": true,
+		"feed":      true,
+	}
 )
 
 // GenerateSlug creates a URL-friendly slug from a string
@@ -33,10 +39,11 @@ func GenerateSlug(s string) string {
 	return slug
 }
 
-// GenerateUniqueSlug creates a unique slug by appending a number if necessary
+// GenerateUniqueSlug creates a unique slug by appending a number if necessary.
+// Reserved slugs are treated as taken.
 func GenerateUniqueSlug(base string, exists func(string) bool) string {
 	slug := GenerateSlug(base)
-	if !exists(slug) {
+	if !reservedSlugs[slug] && !exists(slug) {
 		return slug
 	}
 
@@ -50,3 +57,28 @@ func GenerateUniqueSlug(base string, exists func(string) bool) string {
 		counter++
 	}
 }
+
+// maxTagLength bounds tag names and slugs
+const maxTagLength = 50
+
+// tagSymbols keeps tags such as C++ and C# apart from C
+var tagSymbols = strings.NewReplacer("+", "-plus", "#", "-sharp")
+
+// GenerateTagSlug creates the slug identifying a tag, so that spellings such
+// as "Go", "go " and "GO" are the same tag
+func GenerateTagSlug(name string) string {
+	slug := GenerateSlug(tagSymbols.Replace(strings.ToLower(name)))
+	if len(slug) > maxTagLength {
+		slug = strings.Trim(slug[:maxTagLength], "-")
+	}
+	return slug
+}
+
+// NormalizeTagName trims a tag name and collapses its inner whitespace
+func NormalizeTagName(name string) string {
+	name = strings.Join(strings.Fields(name), " ")
+	if runes := []rune(name); len(runes) > maxTagLength {
+		name = strings.TrimSpace(string(runes[:maxTagLength]))
+	}
+	return name
+}
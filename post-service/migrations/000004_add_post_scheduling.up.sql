@@ -0,0 +1,4 @@
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS publish_at TIMESTAMP WITH TIME ZONE;
+
+-- The scheduler only ever looks at scheduled posts
+CREATE INDEX idx_posts_scheduled ON posts(publish_at) WHERE status = 'scheduled';
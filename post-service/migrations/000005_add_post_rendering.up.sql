@@ -0,0 +1,8 @@
+ALTER TABLE posts
+    ADD COLUMN IF NOT EXISTS content_format VARCHAR(20) NOT NULL DEFAULT 'markdown',
+    ADD COLUMN IF NOT EXISTS content_html TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS toc JSONB NOT NULL DEFAULT '[]',
+    ADD COLUMN IF NOT EXISTS reading_time INT NOT NULL DEFAULT 0;
+
+ALTER TABLE post_revisions
+    ADD COLUMN IF NOT EXISTS content_format VARCHAR(20) NOT NULL DEFAULT 'markdown';
@@ -0,0 +1,6 @@
+ALTER TABLE posts
+    DROP COLUMN IF EXISTS og_image_id,
+    DROP COLUMN IF EXISTS canonical_url,
+    DROP COLUMN IF EXISTS meta_description,
+    DROP COLUMN IF EXISTS meta_title,
+    DROP COLUMN IF EXISTS excerpt;
@@ -0,0 +1,6 @@
+ALTER TABLE posts
+    ADD COLUMN IF NOT EXISTS excerpt TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS meta_title VARCHAR(70) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS meta_description VARCHAR(320) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS canonical_url VARCHAR(2048) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS og_image_id VARCHAR(64) NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS category_slugs;
+DROP TABLE IF EXISTS post_slugs;
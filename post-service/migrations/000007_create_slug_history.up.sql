@@ -0,0 +1,17 @@
+-- Slugs posts and categories were known by before being renamed, so old
+-- links can be redirected to the current slug
+CREATE TABLE IF NOT EXISTS post_slugs (
+    slug VARCHAR(255) PRIMARY KEY,
+    post_id BIGINT NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_post_slugs_post_id ON post_slugs(post_id);
+
+CREATE TABLE IF NOT EXISTS category_slugs (
+    slug VARCHAR(50) PRIMARY KEY,
+    category_id BIGINT NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_category_slugs_category_id ON category_slugs(category_id);
@@ -0,0 +1,3 @@
+CREATE INDEX IF NOT EXISTS idx_posts_published_at ON posts(published_at);
+
+DROP INDEX IF EXISTS idx_posts_published_at_id;
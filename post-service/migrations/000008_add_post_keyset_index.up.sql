@@ -0,0 +1,4 @@
+-- Listings page through posts by (published_at, id), newest first
+CREATE INDEX IF NOT EXISTS idx_posts_published_at_id ON posts(published_at, id);
+
+DROP INDEX IF EXISTS idx_posts_published_at;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_posts_updated_at_id;
+DROP INDEX IF EXISTS idx_posts_title_id;
+DROP INDEX IF EXISTS idx_posts_view_count_id;
@@ -0,0 +1,4 @@
+-- Listings can also be sorted by views, title and last update
+CREATE INDEX IF NOT EXISTS idx_posts_view_count_id ON posts(view_count, id);
+CREATE INDEX IF NOT EXISTS idx_posts_title_id ON posts(title, id);
+CREATE INDEX IF NOT EXISTS idx_posts_updated_at_id ON posts(updated_at, id);
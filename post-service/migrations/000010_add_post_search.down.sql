@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_posts_search;
+
+ALTER TABLE posts DROP COLUMN IF EXISTS search_vector;
+
+DROP FUNCTION IF EXISTS post_tags_text(TEXT[]);
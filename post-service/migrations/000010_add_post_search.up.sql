@@ -0,0 +1,14 @@
+-- array_to_string is only stable, so the generated column needs an immutable
+-- wrapper to index tags
+CREATE OR REPLACE FUNCTION post_tags_text(tags TEXT[]) RETURNS TEXT
+    LANGUAGE sql IMMUTABLE AS $$ SELECT array_to_string(tags, ' ') $$;
+
+-- Full-text search over posts, weighting title over tags over content
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS search_vector TSVECTOR
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+        setweight(to_tsvector('english', coalesce(post_tags_text(tags), '')), 'B') ||
+        setweight(to_tsvector('english', coalesce(content, '')), 'C')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_posts_search ON posts USING gin(search_vector);
@@ -0,0 +1,3 @@
+-- Posts keep their normalized tags
+DROP TABLE IF EXISTS tag_aliases;
+DROP TABLE IF EXISTS tags;
@@ -0,0 +1,45 @@
+CREATE TABLE IF NOT EXISTS tags (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(50) NOT NULL,
+    slug VARCHAR(50) NOT NULL UNIQUE,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Other spellings of a tag, including its former slugs; posts tagged with an
+-- alias are stored with the tag's slug
+CREATE TABLE IF NOT EXISTS tag_aliases (
+    slug VARCHAR(50) PRIMARY KEY,
+    tag_id BIGINT NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_tag_aliases_tag_id ON tag_aliases(tag_id);
+
+-- Existing tags are free-form; normalize them the way utils.GenerateTagSlug
+-- does and store posts with tag slugs from now on
+CREATE FUNCTION tag_slug(name TEXT) RETURNS TEXT LANGUAGE sql IMMUTABLE AS $$
+    SELECT trim(both '-' from left(trim(both '-' from regexp_replace(regexp_replace(
+        replace(replace(replace(lower(name), '+', '-plus'), '#', '-sharp'), ' ', '-'),
+        '[^a-z0-9-]', '', 'g'), '-+', '-', 'g')), 50))
+$$;
+
+INSERT INTO tags (name, slug)
+SELECT DISTINCT ON (tag_slug(name)) left(regexp_replace(trim(name), '\s+', ' ', 'g'), 50), tag_slug(name)
+FROM posts, unnest(posts.tags) AS name
+WHERE tag_slug(name) <> ''
+ORDER BY tag_slug(name), name
+ON CONFLICT (slug) DO NOTHING;
+
+UPDATE posts SET tags = ARRAY(
+    SELECT slug FROM (
+        SELECT tag_slug(name) AS slug, min(position) AS first
+        FROM unnest(posts.tags) WITH ORDINALITY AS t(name, position)
+        GROUP BY 1
+    ) normalized
+    WHERE slug <> ''
+    ORDER BY first
+);
+
+DROP FUNCTION tag_slug(TEXT);
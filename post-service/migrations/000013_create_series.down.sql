@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS series_slugs;
+DROP TABLE IF EXISTS series_posts;
+DROP TABLE IF EXISTS series;
@@ -0,0 +1,29 @@
+-- Series collect posts into ordered multi-part works such as tutorials. A
+-- post belongs to at most one series.
+CREATE TABLE IF NOT EXISTS series (
+    id BIGSERIAL PRIMARY KEY,
+    title VARCHAR(255) NOT NULL,
+    slug VARCHAR(255) NOT NULL UNIQUE,
+    description TEXT NOT NULL DEFAULT '',
+    author_id BIGINT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Positions only order the posts of a series; reordering rewrites them all in
+-- one transaction, so their uniqueness is checked at commit
+CREATE TABLE IF NOT EXISTS series_posts (
+    series_id BIGINT NOT NULL REFERENCES series(id) ON DELETE CASCADE,
+    post_id BIGINT NOT NULL UNIQUE REFERENCES posts(id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    PRIMARY KEY (series_id, post_id),
+    CONSTRAINT series_posts_position_key UNIQUE (series_id, position) DEFERRABLE INITIALLY DEFERRED
+);
+
+CREATE TABLE IF NOT EXISTS series_slugs (
+    slug VARCHAR(255) PRIMARY KEY,
+    series_id BIGINT NOT NULL REFERENCES series(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_series_slugs_series_id ON series_slugs(series_id);
@@ -1,34 +1,76 @@
 package main
 
 import (
-	"log"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
 	"os"
+	"time"
 
+	"github.com/Thedrogon/blogbish/Internals/health"
+	"github.com/Thedrogon/blogbish/Internals/logging"
+	"github.com/Thedrogon/blogbish/Internals/logging/ginlog"
+	"github.com/Thedrogon/blogbish/Internals/metrics/ginmetrics"
+	"github.com/Thedrogon/blogbish/Internals/openapi"
+	"github.com/Thedrogon/blogbish/Internals/tracing"
 	"github.com/Thedrogon/blogbish/search-service/internal/handler"
 	"github.com/Thedrogon/blogbish/search-service/internal/repository"
 	"github.com/Thedrogon/blogbish/search-service/internal/service"
 	"github.com/elastic/go-elasticsearch/v8"
 	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/extra/redisotel/v9"
 	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.opentelemetry.io/otel"
 )
 
 func main() {
+	logger := logging.New("search-service")
+	if err := run(logger); err != nil {
+		logger.Error("service stopped", "error", err)
+		os.Exit(1)
+	}
+}
+
+// run starts the service and blocks until it has shut down. It returns
+// rather than exiting so deferred cleanup, such as flushing traces, still runs.
+func run(logger *slog.Logger) error {
+
+	// Initialize tracing
+	shutdownTracing, err := tracing.Init(context.Background(), "search-service")
+	if err != nil {
+		return fmt.Errorf("error initializing tracing: %w", err)
+	}
+	defer shutdownTracing(context.Background())
+
 	// Initialize Elasticsearch client
 	esClient, err := elasticsearch.NewClient(elasticsearch.Config{
-		Addresses: []string{os.Getenv("ELASTICSEARCH_URL")},
+		Addresses:       []string{os.Getenv("ELASTICSEARCH_URL")},
+		Instrumentation: elasticsearch.NewOpenTelemetryInstrumentation(otel.GetTracerProvider(), false),
 	})
 	if err != nil {
-		log.Fatalf("Error creating Elasticsearch client: %v", err)
+		return fmt.Errorf("error creating Elasticsearch client: %w", err)
 	}
 
 	// Initialize Redis client
 	redisClient := redis.NewClient(&redis.Options{
 		Addr: os.Getenv("REDIS_HOST") + ":" + os.Getenv("REDIS_PORT"),
 	})
+	if err := redisotel.InstrumentTracing(redisClient); err != nil {
+		return fmt.Errorf("error instrumenting Redis client: %w", err)
+	}
 
 	// Initialize repository
 	searchRepo := repository.NewElasticsearchRepository(esClient)
 
+	// Readiness checks
+	checker := health.NewChecker(2 * time.Second)
+	checker.Add("elasticsearch", searchRepo.Ping)
+	checker.Add("redis", func(ctx context.Context) error {
+		return redisClient.Ping(ctx).Err()
+	})
+
 	// Initialize service
 	searchService := service.NewSearchService(searchRepo, redisClient)
 
@@ -36,9 +78,18 @@ func main() {
 	searchHandler := handler.NewSearchHandler(searchService)
 
 	// Initialize router
-	router := gin.Default()
+	router := gin.New()
+	router.Use(otelgin.Middleware("search-service"))
+	router.Use(ginlog.RequestID())
+	router.Use(ginlog.AccessLog(logger))
+	router.Use(ginmetrics.Middleware())
+	router.Use(gin.Recovery())
 
 	// Register routes
+	router.GET("/metrics", ginmetrics.Handler())
+	router.GET("/healthz", gin.WrapF(health.Liveness))
+	router.GET("/readyz", gin.WrapF(checker.Readiness))
+	router.GET("/openapi.json", gin.WrapF(openapi.Handler(handler.OpenAPI())))
 	router.POST("/search", searchHandler.Search)
 	router.POST("/suggest", searchHandler.Suggest)
 	router.POST("/index/post", searchHandler.IndexPost)
@@ -50,7 +101,17 @@ func main() {
 		port = "8084"
 	}
 
-	if err := router.Run(":" + port); err != nil {
-		log.Fatalf("Error starting server: %v", err)
+	srv := &http.Server{
+		Addr:         ":" + port,
+		Handler:      router,
+		IdleTimeout:  time.Minute,
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 30 * time.Second,
 	}
+
+	return health.Serve(srv, checker, logger,
+		// Let pending background cache writes finish before closing Redis
+		health.Closer{Name: "search cache writes", Close: searchService.Flush},
+		health.Closer{Name: "redis", Close: func(context.Context) error { return redisClient.Close() }},
+	)
 }
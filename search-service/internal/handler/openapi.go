@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Thedrogon/blogbish/Internals/openapi"
+	"github.com/Thedrogon/blogbish/Internals/problem"
+	"github.com/Thedrogon/blogbish/search-service/internal/models"
+)
+
+// OpenAPI describes the search service API
+func OpenAPI() *openapi.Document {
+	b := openapi.NewBuilder("Search Service", "1.0.0", "Full-text search and suggestions over posts and comments.")
+	fail := func(description string) openapi.Response { return b.Fails(description, problem.Problem{}) }
+
+	b.Add(http.MethodPost, "/search", openapi.Operation{
+		OperationID: "search",
+		Summary:     "Search posts and comments",
+		Tags:        []string{"search"},
+		RequestBody: openapi.JSON(b.Ref(models.SearchRequest{})),
+		Responses: map[string]openapi.Response{
+			"200": openapi.Returns("Search results", b.Ref(models.SearchResponse{})),
+			"400": fail("Malformed request body"),
+			"422": fail("Validation failed"),
+		},
+	})
+
+	b.Add(http.MethodPost, "/suggest", openapi.Operation{
+		OperationID: "suggest",
+		Summary:     "Suggest completions for a partial query",
+		Tags:        []string{"search"},
+		RequestBody: openapi.JSON(b.Ref(models.SuggestionRequest{})),
+		Responses: map[string]openapi.Response{
+			"200": openapi.Returns("Suggestions", b.Ref(models.SuggestionResponse{})),
+			"400": fail("Malformed request body"),
+			"422": fail("Validation failed"),
+		},
+	})
+
+	b.Add(http.MethodPost, "/index/post", openapi.Operation{
+		OperationID: "indexPost",
+		Summary:     "Index or reindex a post",
+		Tags:        []string{"indexing"},
+		RequestBody: openapi.JSON(b.Ref(models.SearchablePost{})),
+		Responses: map[string]openapi.Response{
+			"201": openapi.Returns("Post indexed", nil),
+			"400": fail("Malformed request body"),
+		},
+	})
+
+	b.Add(http.MethodPost, "/index/comment", openapi.Operation{
+		OperationID: "indexComment",
+		Summary:     "Index or reindex a comment",
+		Tags:        []string{"indexing"},
+		RequestBody: openapi.JSON(b.Ref(models.SearchableComment{})),
+		Responses: map[string]openapi.Response{
+			"201": openapi.Returns("Comment indexed", nil),
+			"400": fail("Malformed request body"),
+		},
+	})
+
+	return b.Document()
+}
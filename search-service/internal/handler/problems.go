@@ -0,0 +1,7 @@
+package handler
+
+import "github.com/Thedrogon/blogbish/Internals/problem"
+
+// problems maps domain errors to the problem returned to clients. The search
+// service has none yet, so every error is reported as an internal error.
+var problems problem.Mappings
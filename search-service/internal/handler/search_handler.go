@@ -3,6 +3,7 @@ package handler
 import (
 	"net/http"
 
+	"github.com/Thedrogon/blogbish/Internals/problem/ginproblem"
 	"github.com/Thedrogon/blogbish/search-service/internal/models"
 	"github.com/Thedrogon/blogbish/search-service/internal/service"
 	"github.com/gin-gonic/gin"
@@ -21,7 +22,7 @@ func NewSearchHandler(searchService *service.SearchService) *SearchHandler {
 func (h *SearchHandler) Search(c *gin.Context) {
 	var req models.SearchRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		ginproblem.BindError(c, err)
 		return
 	}
 
@@ -38,7 +39,7 @@ func (h *SearchHandler) Search(c *gin.Context) {
 
 	result, err := h.searchService.Search(c.Request.Context(), &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		ginproblem.WriteError(c, problems, err)
 		return
 	}
 
@@ -48,7 +49,7 @@ func (h *SearchHandler) Search(c *gin.Context) {
 func (h *SearchHandler) Suggest(c *gin.Context) {
 	var req models.SuggestionRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		ginproblem.BindError(c, err)
 		return
 	}
 
@@ -59,7 +60,7 @@ func (h *SearchHandler) Suggest(c *gin.Context) {
 
 	result, err := h.searchService.Suggest(c.Request.Context(), &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		ginproblem.WriteError(c, problems, err)
 		return
 	}
 
@@ -69,12 +70,12 @@ func (h *SearchHandler) Suggest(c *gin.Context) {
 func (h *SearchHandler) IndexPost(c *gin.Context) {
 	var post models.SearchablePost
 	if err := c.ShouldBindJSON(&post); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		ginproblem.BindError(c, err)
 		return
 	}
 
 	if err := h.searchService.IndexPost(c.Request.Context(), &post); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		ginproblem.WriteError(c, problems, err)
 		return
 	}
 
@@ -84,12 +85,12 @@ func (h *SearchHandler) IndexPost(c *gin.Context) {
 func (h *SearchHandler) IndexComment(c *gin.Context) {
 	var comment models.SearchableComment
 	if err := c.ShouldBindJSON(&comment); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		ginproblem.BindError(c, err)
 		return
 	}
 
 	if err := h.searchService.IndexComment(c.Request.Context(), &comment); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		ginproblem.WriteError(c, problems, err)
 		return
 	}
 
@@ -316,3 +316,31 @@ func mustMarshal(v interface{}) []byte {
 	}
 	return b
 }
+
+// Ping checks that the cluster is reachable and not in a red state
+func (r *ElasticsearchRepository) Ping(ctx context.Context) error {
+	res, err := r.client.Cluster.Health(
+		r.client.Cluster.Health.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to get cluster health: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return fmt.Errorf("failed to get cluster health: %s", res.String())
+	}
+
+	var health struct {
+		Status string `json:"status"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&health); err != nil {
+		return fmt.Errorf("failed to decode cluster health: %w", err)
+	}
+
+	if health.Status == "red" {
+		return fmt.Errorf("cluster status is red")
+	}
+
+	return nil
+}
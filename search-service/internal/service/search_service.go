@@ -5,8 +5,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/Thedrogon/blogbish/Internals/cachekeys"
+	"github.com/Thedrogon/blogbish/Internals/metrics"
 	"github.com/Thedrogon/blogbish/search-service/internal/models"
 	"github.com/Thedrogon/blogbish/search-service/internal/repository"
 	"github.com/redis/go-redis/v9"
@@ -15,6 +18,9 @@ import (
 type SearchService struct {
 	repo  repository.SearchRepository
 	cache *redis.Client
+
+	// pending tracks background cache writes so they can be flushed on shutdown
+	pending sync.WaitGroup
 }
 
 func NewSearchService(repo repository.SearchRepository, cache *redis.Client) *SearchService {
@@ -27,11 +33,12 @@ func NewSearchService(repo repository.SearchRepository, cache *redis.Client) *Se
 func (s *SearchService) Search(ctx context.Context, req *models.SearchRequest) (*models.SearchResponse, error) {
 	// Try to get from cache first
 	cacheKey := generateCacheKey(req)
-	if cachedResult, err := s.getFromCache(ctx, cacheKey); err == nil {
-		if response, ok := cachedResult.(*models.SearchResponse); ok {
-			return response, nil
-		}
+	var cached models.SearchResponse
+	if err := s.getFromCache(ctx, cacheKey, &cached); err == nil {
+		metrics.CacheHit("search")
+		return &cached, nil
 	}
+	metrics.CacheMiss("search")
 
 	// If not in cache, perform search
 	result, err := s.repo.Search(ctx, req)
@@ -40,7 +47,7 @@ func (s *SearchService) Search(ctx context.Context, req *models.SearchRequest) (
 	}
 
 	// Cache the result
-	go s.cacheResult(context.Background(), cacheKey, result)
+	s.cacheAsync(cacheKey, result)
 
 	return result, nil
 }
@@ -48,11 +55,12 @@ func (s *SearchService) Search(ctx context.Context, req *models.SearchRequest) (
 func (s *SearchService) Suggest(ctx context.Context, req *models.SuggestionRequest) (*models.SuggestionResponse, error) {
 	// Try to get from cache first
 	cacheKey := generateSuggestionCacheKey(req)
-	if cachedResult, err := s.getFromCache(ctx, cacheKey); err == nil {
-		if response, ok := cachedResult.(*models.SuggestionResponse); ok {
-			return response, nil
-		}
+	var cached models.SuggestionResponse
+	if err := s.getFromCache(ctx, cacheKey, &cached); err == nil {
+		metrics.CacheHit("suggest")
+		return &cached, nil
 	}
+	metrics.CacheMiss("suggest")
 
 	// If not in cache, get suggestions
 	result, err := s.repo.Suggest(ctx, req)
@@ -61,7 +69,7 @@ func (s *SearchService) Suggest(ctx context.Context, req *models.SuggestionReque
 	}
 
 	// Cache the result
-	go s.cacheResult(context.Background(), cacheKey, result)
+	s.cacheAsync(cacheKey, result)
 
 	return result, nil
 }
@@ -74,20 +82,39 @@ func (s *SearchService) IndexComment(ctx context.Context, comment *models.Search
 	return s.repo.IndexComment(ctx, comment)
 }
 
+// Flush waits for background cache writes to complete or for ctx to expire
+func (s *SearchService) Flush(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.pending.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // Helper functions for caching
 
-func (s *SearchService) getFromCache(ctx context.Context, key string) (interface{}, error) {
+func (s *SearchService) getFromCache(ctx context.Context, key string, dest interface{}) error {
 	data, err := s.cache.Get(ctx, key).Result()
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	var result interface{}
-	if err := json.Unmarshal([]byte(data), &result); err != nil {
-		return nil, err
-	}
+	return json.Unmarshal([]byte(data), dest)
+}
 
-	return result, nil
+func (s *SearchService) cacheAsync(key string, result interface{}) {
+	s.pending.Add(1)
+	go func() {
+		defer s.pending.Done()
+		_ = s.cacheResult(context.Background(), key, result)
+	}()
 }
 
 func (s *SearchService) cacheResult(ctx context.Context, key string, result interface{}) error {
@@ -100,7 +127,7 @@ func (s *SearchService) cacheResult(ctx context.Context, key string, result inte
 }
 
 func generateCacheKey(req *models.SearchRequest) string {
-	return fmt.Sprintf("search:%s:%s:%d:%d:%s:%s:%s",
+	return fmt.Sprintf(cachekeys.Search+"%s:%s:%d:%d:%s:%s:%s",
 		req.Query,
 		req.Type,
 		req.From,
@@ -112,7 +139,7 @@ func generateCacheKey(req *models.SearchRequest) string {
 }
 
 func generateSuggestionCacheKey(req *models.SuggestionRequest) string {
-	return fmt.Sprintf("suggest:%s:%s:%d:%s",
+	return fmt.Sprintf(cachekeys.Suggest+"%s:%s:%d:%s",
 		req.Query,
 		req.Type,
 		req.Limit,